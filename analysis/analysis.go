@@ -0,0 +1,137 @@
+// Package analysis looks at deals with every card known: it solves them with
+// perfect play and measures how much each card played cost its team.
+package analysis
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// CardLoss measures a played card against the best card its player had, with
+// every card known and perfect play from there on.
+type CardLoss struct {
+	// Trick is the number of the trick the card was played to, from 1.
+	Trick    int           `json:"trick"`
+	Player   game.PlayerId `json:"player"`
+	Card     game.Card     `json:"card"`
+	BestCard game.Card     `json:"bestCard"`
+
+	// Loss is the points the player's team gave away by playing Card
+	// instead of BestCard. It is 0 when Card was as good as any.
+	Loss int `json:"loss"`
+}
+
+// AnalyzeHand replays the cards played in hand from the deal and measures
+// each of them against perfect play. Only card points, the last trick bonus
+// and capot are counted.
+func AnalyzeHand(hand *game.Hand) ([]CardLoss, error) {
+	state := hand.GetState()
+	if state != game.HandInProgress && state != game.HandFinished {
+		return nil, fmt.Errorf("cannot analyze a hand in state %s", state)
+	}
+	if err := checkTable(hand.GetRules()); err != nil {
+		return nil, err
+	}
+
+	tricks := hand.CompletedTricks
+	if state == game.HandInProgress {
+		tricks = append(tricks[:len(tricks):len(tricks)], hand.CurrentTrick)
+	}
+
+	s := newSolver(hand.GetTrump(), hand.GetRules())
+	for player, cards := range hand.InitialCards {
+		for _, card := range cards {
+			s.hands[seat(player)] |= 1 << s.bit(card)
+		}
+	}
+	if len(tricks) > 0 {
+		s.leader = seat(tricks[0].StartingPlayer)
+	}
+
+	var losses []CardLoss
+	for i, trick := range tricks {
+		player := trick.StartingPlayer
+		for range len(trick.Cards) {
+			card := trick.Cards[player]
+			loss, err := s.measure(player, card)
+			if err != nil {
+				return nil, fmt.Errorf("trick %d: %w", i+1, err)
+			}
+			loss.Trick = i + 1
+			losses = append(losses, loss)
+
+			s.play(s.bit(card))
+			player = player.GetNextPlayerId()
+		}
+	}
+
+	return losses, nil
+}
+
+// measure compares card with the best card player may play from the position.
+func (s *solver) measure(player game.PlayerId, card game.Card) (CardLoss, error) {
+	current := s.currentSeat()
+	if current != seat(player) {
+		return CardLoss{}, fmt.Errorf("expected player %d to play, got player %d", game.Player1+game.PlayerId(current), player)
+	}
+
+	played := s.bit(card)
+	legal := s.legal(current)
+	if legal&(1<<played) == 0 {
+		return CardLoss{}, fmt.Errorf("%s cannot be played", card.String())
+	}
+
+	team := teamIndex(current)
+	points := func(bit int) int {
+		gained, u := s.play(bit)
+		value := gained + s.value()
+		s.unplay(bit, u)
+		return s.teamPoints(value, team)
+	}
+
+	loss := CardLoss{Player: player, Card: card, BestCard: card}
+	playedPoints := points(played)
+	bestPoints := playedPoints
+
+	var moves [game.NUM_CARDS_PER_PLAYER]int
+	for _, bit := range moves[:s.orderMoves(&moves)] {
+		if p := points(bit); p > bestPoints {
+			loss.BestCard, bestPoints = s.cards[bit], p
+		}
+	}
+	loss.Loss = bestPoints - playedPoints
+
+	return loss, nil
+}
+
+// teamPoints splits value, what Team1 scores over Team2 from the position,
+// into the points team scores. Both teams share the points left in play,
+// unless a capot changes what they are worth.
+func (s *solver) teamPoints(value int, team int) int {
+	total := s.lastTrickPoints
+	for rest := s.remaining(); rest != 0; rest &= rest - 1 {
+		total += s.points[bits.TrailingZeros32(rest)]
+	}
+	for _, bit := range s.played {
+		if bit != noCard {
+			total += s.points[bit]
+		}
+	}
+
+	team1 := (total + value) / 2
+	if capot := total + s.capotPoints - s.totalPoints - s.lastTrickPoints; s.capotPoints > 0 && capot != total {
+		switch {
+		case value == capot && !s.taken[1]:
+			team1 = capot
+		case value == -capot && !s.taken[0]:
+			team1 = 0
+		}
+	}
+
+	if team == 0 {
+		return team1
+	}
+	return team1 - value
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+func TestAnalyzeHandFindsNoLossInPerfectPlay(t *testing.T) {
+	hand := newDealtHand(t, 6, game.DefaultRuleSet())
+	solution, err := Solve(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, card := range solution.Line {
+		player, _ := hand.GetCurrentTurn()
+		if err := hand.PlayCard(player, card, game.Announcement{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	losses, err := AnalyzeHand(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(losses) != game.NUM_PLAYERS*game.NUM_CARDS_PER_PLAYER {
+		t.Fatalf("expected every card to be analyzed, got %d", len(losses))
+	}
+	for _, loss := range losses {
+		if loss.Loss != 0 {
+			t.Errorf("expected no loss in perfect play, got %+v", loss)
+		}
+	}
+}
+
+func TestAnalyzeHandMeasuresLosses(t *testing.T) {
+	hand := newDealtHand(t, 8, game.DefaultRuleSet())
+	solutions, err := SolveCards(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 6 {
+		playFirstLegalCard(t, hand)
+	}
+
+	losses, err := AnalyzeHand(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(losses) != 6 {
+		t.Fatalf("expected the 6 cards played to be analyzed, got %d", len(losses))
+	}
+
+	first := losses[0]
+	team := first.Player.GetTeam()
+	expected := solutions[first.BestCard].Points[team] - solutions[first.Card].Points[team]
+	if first.Trick != 1 || first.Player != hand.StartingPlayer {
+		t.Errorf("expected the first card to be the starting player's, got %+v", first)
+	}
+	if first.Loss != expected {
+		t.Errorf("expected the first card to lose %d points, got %d", expected, first.Loss)
+	}
+	for _, loss := range losses {
+		if loss.Loss < 0 {
+			t.Errorf("expected no negative loss, got %+v", loss)
+		}
+	}
+	if losses[4].Trick != 2 {
+		t.Errorf("expected the fifth card to be in the second trick, got %d", losses[4].Trick)
+	}
+}
@@ -0,0 +1,543 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// Solution is the outcome of perfect play from a position in which every card
+// is known.
+type Solution struct {
+	// Points holds the points each team scores from the remaining tricks:
+	// card points, the last trick bonus and capot, as the rules count them.
+	// Declarations and Belote are not included.
+	Points map[game.TeamId]int `json:"points"`
+
+	// Line is one sequence of optimal cards from the position, in play order.
+	Line []game.Card `json:"line"`
+}
+
+// Solve finds the points each team scores from the position of hand when
+// all four players play perfectly with every card visible.
+func Solve(hand *game.Hand) (Solution, error) {
+	s, err := newSolverFromHand(hand)
+	if err != nil {
+		return Solution{}, err
+	}
+	return s.solveLine(), nil
+}
+
+// SolveCards solves the position of hand once for every card the player to
+// move may play, with that card played first.
+func SolveCards(hand *game.Hand) (map[game.Card]Solution, error) {
+	s, err := newSolverFromHand(hand)
+	if err != nil {
+		return nil, err
+	}
+	return s.solveMoves(), nil
+}
+
+const (
+	numSeats = game.NUM_PLAYERS
+	noCard   = -1
+	noSuit   = -1
+)
+
+var solverSuits = []game.Suit{game.Spades, game.Hearts, game.Diamonds, game.Clubs}
+
+// solver runs an alpha-beta search over a deal in which every card is known.
+// Cards are bits of a uint32: eight bits per suit, ordered so that a higher
+// bit takes a lower one of the same suit. Trick boundaries are cached by the
+// cards left, the leader and which teams have taken a trick, which is all
+// the value of the rest of the hand depends on once the deal is fixed.
+type solver struct {
+	contract game.Contract
+	// trump is the index of the trump suit in solverSuits, noSuit without one.
+	trump       int
+	cards       [32]game.Card
+	points      [32]int
+	totalPoints int
+
+	lastTrickPoints int
+	capotPoints     int
+
+	hands  [numSeats]uint32
+	leader int
+	played [numSeats]int
+	count  int
+	taken  [2]bool
+
+	cache []cacheEntry
+}
+
+const cacheBits = 18
+
+// cacheEntry holds what is known of the value of a cached position. Entries
+// are indexed by a hash of their key and the newest position wins.
+type cacheEntry struct {
+	key          uint64
+	lower, upper int32
+}
+
+// undo restores the solver after a card is taken back.
+type undo struct {
+	leader int
+	count  int
+	played [numSeats]int
+	taken  [2]bool
+}
+
+func newSolver(contract game.Contract, rules game.RuleSet) *solver {
+	s := &solver{
+		contract:        contract,
+		trump:           noSuit,
+		lastTrickPoints: rules.LastTrickPoints,
+		capotPoints:     rules.CapotPoints,
+		cache:           make([]cacheEntry, 1<<cacheBits),
+	}
+	for i := range s.played {
+		s.played[i] = noCard
+	}
+
+	trump, hasTrump := contract.TrumpSuit()
+	for i, suit := range solverSuits {
+		if hasTrump && suit == trump {
+			s.trump = i
+		}
+	}
+	for _, card := range game.NewDeck() {
+		bit := s.bit(card)
+		s.cards[bit] = card
+		s.points[bit] = contract.CardPoints(card)
+		s.totalPoints += s.points[bit]
+	}
+
+	return s
+}
+
+func newSolverFromHand(hand *game.Hand) (*solver, error) {
+	if hand.GetState() != game.HandInProgress {
+		return nil, fmt.Errorf("cannot solve a hand in state %s", hand.GetState())
+	}
+	if err := checkTable(hand.GetRules()); err != nil {
+		return nil, err
+	}
+
+	s := newSolver(hand.GetTrump(), hand.GetRules())
+	for player := game.Player1; player <= game.Player4; player++ {
+		for _, card := range hand.GetPlayerCards(player).Cards() {
+			s.hands[seat(player)] |= 1 << s.bit(card)
+		}
+	}
+
+	for _, trick := range hand.CompletedTricks {
+		result, err := trick.GetTrickResult()
+		if err != nil {
+			return nil, err
+		}
+		s.taken[teamIndex(seat(result.WinnerPlayer))] = true
+	}
+
+	trick := hand.CurrentTrick
+	s.leader = seat(trick.StartingPlayer)
+	for player := trick.StartingPlayer; s.count < len(trick.Cards); player = player.GetNextPlayerId() {
+		s.played[seat(player)] = s.bit(trick.Cards[player])
+		s.count++
+	}
+
+	return s, nil
+}
+
+// reset sets the solver up for a new deal, with startingPlayer to lead the
+// first trick.
+func (s *solver) reset(deal map[game.PlayerId][]game.Card, startingPlayer game.PlayerId) {
+	clear(s.cache)
+	s.hands = [numSeats]uint32{}
+	for player, cards := range deal {
+		for _, card := range cards {
+			s.hands[seat(player)] |= 1 << s.bit(card)
+		}
+	}
+	s.leader = seat(startingPlayer)
+	s.played = [numSeats]int{noCard, noCard, noCard, noCard}
+	s.count = 0
+	s.taken = [2]bool{}
+}
+
+// checkTable rejects tables other than four players in partnerships, the
+// only one the solver plays.
+func checkTable(rules game.RuleSet) error {
+	if players := rules.Table().Players(); players != numSeats {
+		return fmt.Errorf("cannot solve a hand of %d players", players)
+	}
+	return nil
+}
+
+func seat(player game.PlayerId) int {
+	return int(player - game.Player1)
+}
+
+func teamIndex(seat int) int {
+	return seat % 2
+}
+
+func (s *solver) bit(card game.Card) int {
+	for i, suit := range solverSuits {
+		if suit == card.Suit {
+			return i*game.NUM_CARD_VALUES + s.contract.TrickOrder(card)
+		}
+	}
+	panic(fmt.Sprintf("unknown suit %s", card.Suit))
+}
+
+func suitMask(suit int) uint32 {
+	return 0xFF << (suit * game.NUM_CARD_VALUES)
+}
+
+func (s *solver) currentSeat() int {
+	return (s.leader + s.count) % numSeats
+}
+
+// legal returns the cards seat may play, following the rules of Trick.
+func (s *solver) legal(seat int) uint32 {
+	hand := s.hands[seat]
+	if s.count == 0 {
+		return hand
+	}
+
+	lead := s.played[s.leader] / game.NUM_CARD_VALUES
+	if follow := hand & suitMask(lead); follow != 0 {
+		if !s.contract.IsTrump(solverSuits[lead]) {
+			return follow
+		}
+		return s.overtrump(follow, lead)
+	}
+	if s.trump == noSuit {
+		return hand
+	}
+	if trumps := hand & suitMask(s.trump); trumps != 0 {
+		return s.overtrump(trumps, s.trump)
+	}
+	return hand
+}
+
+// overtrump keeps the trumps of suit beating every card of suit on the table,
+// if there are any.
+func (s *solver) overtrump(trumps uint32, suit int) uint32 {
+	highest := noCard
+	for _, bit := range s.played {
+		if bit != noCard && bit/game.NUM_CARD_VALUES == suit && bit > highest {
+			highest = bit
+		}
+	}
+	if highest == noCard {
+		return trumps
+	}
+	if higher := trumps &^ (1<<(highest+1) - 1); higher != 0 {
+		return higher
+	}
+	return trumps
+}
+
+// play puts bit on the table for the current seat. When the card completes a
+// trick, the trick is gathered and the points it is worth to Team1 over Team2
+// are returned.
+func (s *solver) play(bit int) (int, undo) {
+	u := undo{leader: s.leader, count: s.count, played: s.played, taken: s.taken}
+
+	current := s.currentSeat()
+	s.hands[current] &^= 1 << bit
+	s.played[current] = bit
+	s.count++
+	if s.count < numSeats {
+		return 0, u
+	}
+
+	winner := s.leader
+	points := 0
+	for i := range numSeats {
+		player := (s.leader + i) % numSeats
+		points += s.points[s.played[player]]
+		if s.beats(s.played[player], s.played[winner]) {
+			winner = player
+		}
+	}
+	if s.remaining() == 0 {
+		points += s.lastTrickPoints
+	}
+
+	s.leader = winner
+	s.count = 0
+	s.taken[teamIndex(winner)] = true
+	for i := range s.played {
+		s.played[i] = noCard
+	}
+
+	if teamIndex(winner) == 0 {
+		return points, u
+	}
+	return -points, u
+}
+
+func (s *solver) unplay(bit int, u undo) {
+	s.leader, s.count, s.played, s.taken = u.leader, u.count, u.played, u.taken
+	s.hands[s.currentSeat()] |= 1 << bit
+}
+
+func (s *solver) beats(bit, best int) bool {
+	suit, bestSuit := bit/game.NUM_CARD_VALUES, best/game.NUM_CARD_VALUES
+	if suit == bestSuit {
+		return bit > best
+	}
+	return suit == s.trump
+}
+
+func (s *solver) remaining() uint32 {
+	return s.hands[0] | s.hands[1] | s.hands[2] | s.hands[3]
+}
+
+// capotAdjustment is what a capot adds to the points a team scored from its
+// tricks once the hand is over: capot points replace its card points and the
+// last trick bonus.
+func (s *solver) capotAdjustment() int {
+	if s.capotPoints == 0 || s.taken[0] == s.taken[1] {
+		return 0
+	}
+
+	adjustment := s.capotPoints - s.totalPoints - s.lastTrickPoints
+	if s.taken[0] {
+		return adjustment
+	}
+	return -adjustment
+}
+
+func (s *solver) key() uint64 {
+	key := uint64(s.remaining()) | uint64(s.leader)<<32
+	for i, taken := range s.taken {
+		if taken {
+			key |= 1 << (34 + i)
+		}
+	}
+	return key
+}
+
+// search returns the points Team1 scores over Team2 from the position with
+// perfect play, exactly when it lies between alpha and beta and as a bound
+// otherwise.
+func (s *solver) search(alpha, beta int) int {
+	remaining := s.remaining()
+	if remaining == 0 {
+		return s.capotAdjustment()
+	}
+
+	var entry *cacheEntry
+	var key uint64
+	alphaOrig, betaOrig := alpha, beta
+	if s.count == 0 {
+		key = s.key()
+		entry = &s.cache[(key*0x9E3779B97F4A7C15)>>(64-cacheBits)]
+		if entry.key == key {
+			lower, upper := int(entry.lower), int(entry.upper)
+			if lower == upper || lower >= beta {
+				return lower
+			}
+			if upper <= alpha {
+				return upper
+			}
+			alpha, beta = max(alpha, lower), min(beta, upper)
+		}
+	}
+
+	maximizing := teamIndex(s.currentSeat()) == 0
+	best := math.MinInt
+	if !maximizing {
+		best = math.MaxInt
+	}
+
+	var moves [game.NUM_CARDS_PER_PLAYER]int
+	for _, bit := range moves[:s.orderMoves(&moves)] {
+		gained, u := s.play(bit)
+		value := gained + s.search(alpha-gained, beta-gained)
+		s.unplay(bit, u)
+
+		if maximizing {
+			best = max(best, value)
+			alpha = max(alpha, value)
+		} else {
+			best = min(best, value)
+			beta = min(beta, value)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	if entry != nil {
+		if entry.key != key {
+			*entry = cacheEntry{key: key, lower: math.MinInt32, upper: math.MaxInt32}
+		}
+		switch {
+		case best <= alphaOrig:
+			entry.upper = min(entry.upper, int32(best))
+		case best >= betaOrig:
+			entry.lower = max(entry.lower, int32(best))
+		default:
+			entry.lower, entry.upper = int32(best), int32(best)
+		}
+	}
+
+	return best
+}
+
+// value returns the exact value of the position. It narrows the value down
+// with null window searches, which the cached bounds make cheap to repeat.
+func (s *solver) value() int {
+	guess := 0
+	lower, upper := math.MinInt/2, math.MaxInt/2
+	for lower < upper {
+		beta := guess
+		if guess == lower {
+			beta = guess + 1
+		}
+		guess = s.search(beta-1, beta)
+		if guess < beta {
+			upper = guess
+		} else {
+			lower = guess
+		}
+	}
+	return guess
+}
+
+// bestMove returns a best card for the current seat. Once the value of the
+// position is known, a null window search is enough to tell whether a card
+// reaches it.
+func (s *solver) bestMove() int {
+	target := s.value()
+
+	var moves [game.NUM_CARDS_PER_PLAYER]int
+	n := s.orderMoves(&moves)
+	for _, bit := range moves[:n] {
+		gained, u := s.play(bit)
+		value := gained + s.search(target-gained-1, target-gained+1)
+		s.unplay(bit, u)
+
+		if value == target {
+			return bit
+		}
+	}
+	panic("no card reaches the value of the position")
+}
+
+// orderMoves fills moves with the legal cards of the current seat, most
+// promising first, and returns how many there are. Leads go from the most
+// valuable card down. Following, cards taking the trick come first, then the
+// others from the cheapest up, or from the most valuable down when partner is
+// winning the trick.
+func (s *solver) orderMoves(moves *[game.NUM_CARDS_PER_PLAYER]int) int {
+	current := s.currentSeat()
+	legal := s.legal(current)
+
+	winning := legal
+	partnerWinning := false
+	if s.count > 0 {
+		winner := s.leader
+		for player, bit := range s.played {
+			if bit != noCard && s.beats(bit, s.played[winner]) {
+				winner = player
+			}
+		}
+		partnerWinning = teamIndex(winner) == teamIndex(current)
+
+		winning = 0
+		for rest := legal; rest != 0; rest &= rest - 1 {
+			if bit := bits.TrailingZeros32(rest); s.beats(bit, s.played[winner]) {
+				winning |= 1 << bit
+			}
+		}
+	}
+
+	n := 0
+	for rest := winning; rest != 0; n++ {
+		bit := 31 - bits.LeadingZeros32(rest)
+		rest &^= 1 << bit
+		moves[n] = bit
+	}
+	if s.count == 0 {
+		slices.SortStableFunc(moves[:n], func(b1, b2 int) int { return s.points[b2] - s.points[b1] })
+	} else if s.count == numSeats-1 {
+		// The last card of a trick need not be any higher than it takes.
+		slices.Reverse(moves[:n])
+	}
+
+	losing := n
+	for rest := legal &^ winning; rest != 0; rest &= rest - 1 {
+		moves[n] = bits.TrailingZeros32(rest)
+		n++
+	}
+	slices.SortFunc(moves[losing:n], func(b1, b2 int) int {
+		if partnerWinning {
+			return s.points[b2] - s.points[b1]
+		}
+		return s.points[b1] - s.points[b2]
+	})
+
+	return n
+}
+
+// solveLine plays the position out with optimal cards and scores the line.
+func (s *solver) solveLine() Solution {
+	solution := Solution{Points: map[game.TeamId]int{game.Team1: 0, game.Team2: 0}}
+
+	var undos []undo
+	var played []int
+	for s.remaining() != 0 {
+		bit := s.bestMove()
+		gained, u := s.play(bit)
+		undos, played = append(undos, u), append(played, bit)
+		solution.Line = append(solution.Line, s.cards[bit])
+
+		if gained > 0 {
+			solution.Points[game.Team1] += gained
+		} else {
+			solution.Points[game.Team2] -= gained
+		}
+	}
+
+	if adjustment := s.capotAdjustment(); s.taken[0] {
+		solution.Points[game.Team1] += adjustment
+	} else {
+		solution.Points[game.Team2] -= adjustment
+	}
+
+	for i := len(played) - 1; i >= 0; i-- {
+		s.unplay(played[i], undos[i])
+	}
+
+	return solution
+}
+
+func (s *solver) solveMoves() map[game.Card]Solution {
+	solutions := map[game.Card]Solution{}
+	for moves := s.legal(s.currentSeat()); moves != 0; {
+		bit := 31 - bits.LeadingZeros32(moves)
+		moves &^= 1 << bit
+
+		gained, u := s.play(bit)
+		solution := s.solveLine()
+		s.unplay(bit, u)
+
+		solution.Line = append([]game.Card{s.cards[bit]}, solution.Line...)
+		if gained > 0 {
+			solution.Points[game.Team1] += gained
+		} else {
+			solution.Points[game.Team2] -= gained
+		}
+		solutions[s.cards[bit]] = solution
+	}
+	return solutions
+}
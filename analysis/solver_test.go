@@ -0,0 +1,208 @@
+package analysis
+
+import (
+	"math/bits"
+	"slices"
+	"testing"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// newDealtHand returns the first hand of a seeded game, with the table trump
+// taken and no doubling, ready for its first card.
+func newDealtHand(t testing.TB, seed uint64, rules game.RuleSet) *game.Hand {
+	t.Helper()
+
+	rules.ContraAllowed = false
+	gm := game.NewBeloteGameWithDealer(rules, game.NewSeededDealerFactory(seed))
+	gm.Start()
+
+	hand := gm.GetHand()
+	if hand.GetState() == game.TableTrumpSelection {
+		player, _ := hand.GetCurrentTurn()
+		if err := gm.AcceptTableTrump(player, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return hand
+}
+
+func playFirstLegalCard(t testing.TB, hand *game.Hand) {
+	t.Helper()
+
+	player, _ := hand.GetCurrentTurn()
+	if err := hand.PlayCard(player, hand.LegalCards(player)[0], game.Announcement{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// bruteForce returns what Team1 scores over Team2 from the position of hand,
+// by trying every line of play on hand clones.
+func bruteForce(hand *game.Hand) int {
+	if hand.GetState() == game.HandFinished {
+		totals := hand.GetTotals()
+		return totals[game.Team1] - totals[game.Team2]
+	}
+
+	player, _ := hand.GetCurrentTurn()
+	var best *int
+	for _, card := range hand.LegalCards(player) {
+		next := hand.Clone()
+		if err := next.PlayCard(player, card, game.Announcement{}); err != nil {
+			panic(err)
+		}
+		value := bruteForce(next)
+		if best == nil || (player.GetTeam() == game.Team1 && value > *best) || (player.GetTeam() == game.Team2 && value < *best) {
+			best = &value
+		}
+	}
+	return *best
+}
+
+func TestSolverLegalCardsMatchTrickRules(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		hand := newDealtHand(t, seed, game.DefaultRuleSet())
+		for hand.GetState() == game.HandInProgress {
+			s, err := newSolverFromHand(hand)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			player, _ := hand.GetCurrentTurn()
+			var legal []game.Card
+			for moves := s.legal(seat(player)); moves != 0; moves &= moves - 1 {
+				legal = append(legal, s.cards[bits.TrailingZeros32(moves)])
+			}
+			slices.SortFunc(legal, game.CompareCards)
+
+			if !slices.Equal(legal, hand.LegalCards(player)) {
+				t.Fatalf("seed %d: expected legal cards %v, got %v", seed, hand.LegalCards(player), legal)
+			}
+			playFirstLegalCard(t, hand)
+		}
+	}
+}
+
+func TestSolveMatchesBruteForce(t *testing.T) {
+	rules := game.DefaultRuleSet()
+
+	for seed := uint64(0); seed < 10; seed++ {
+		hand := newDealtHand(t, seed, rules)
+		for range 4*game.NUM_CARDS_PER_PLAYER - 13 {
+			playFirstLegalCard(t, hand)
+		}
+
+		solution, err := Solve(hand)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		totals := hand.GetTotals()
+		expected := bruteForce(hand) - (totals[game.Team1] - totals[game.Team2])
+		if got := solution.Points[game.Team1] - solution.Points[game.Team2]; got != expected {
+			t.Errorf("seed %d: expected Team1 to score %d over Team2, got %d", seed, expected, got)
+		}
+
+		for _, card := range solution.Line {
+			player, _ := hand.GetCurrentTurn()
+			if err := hand.PlayCard(player, card, game.Announcement{}); err != nil {
+				t.Fatalf("seed %d: line plays %v illegally: %v", seed, card, err)
+			}
+		}
+		if hand.GetState() != game.HandFinished {
+			t.Errorf("seed %d: expected the line to finish the hand", seed)
+		}
+	}
+}
+
+func TestSolveScoresCapot(t *testing.T) {
+	rules, _ := game.GetRuleSetPreset(game.BonusesRuleSetPreset)
+	hand := newDealtHand(t, 0, rules)
+
+	// Player1 holds every trump and Player3 the side Aces and Tens, so Team1
+	// leads and takes every trick.
+	var trumps, masters, rest []game.Card
+	for _, card := range game.NewDeck() {
+		switch {
+		case hand.GetTrump().IsTrump(card.Suit):
+			trumps = append(trumps, card)
+		case card.Rank == game.Ace || card.Rank == game.Ten:
+			masters = append(masters, card)
+		default:
+			rest = append(rest, card)
+		}
+	}
+	masters = append(masters, rest[:2]...)
+	rest = rest[2:]
+
+	deal := map[game.PlayerId][]game.Card{
+		game.Player1: trumps,
+		game.Player2: rest[:game.NUM_CARDS_PER_PLAYER],
+		game.Player3: masters,
+		game.Player4: rest[game.NUM_CARDS_PER_PLAYER:],
+	}
+	for player, cards := range deal {
+		hand.PlayerCards[player] = game.NewCardSet(cards...)
+	}
+	hand.CurrentTrick = game.NewTrick(game.Player1, hand.GetTrump(), game.FourPlayerTable)
+
+	solution, err := Solve(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solution.Points[game.Team1] != rules.CapotPoints || solution.Points[game.Team2] != 0 {
+		t.Errorf("expected a capot of %d, got %v", rules.CapotPoints, solution.Points)
+	}
+}
+
+func TestSolveCardsScoresEveryLegalCard(t *testing.T) {
+	hand := newDealtHand(t, 4, game.DefaultRuleSet())
+	playFirstLegalCard(t, hand)
+
+	solutions, err := SolveCards(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best, err := Solve(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player, _ := hand.GetCurrentTurn()
+	team := player.GetTeam()
+	if len(solutions) != len(hand.LegalCards(player)) {
+		t.Fatalf("expected a solution per legal card, got %d", len(solutions))
+	}
+	for card, solution := range solutions {
+		if solution.Line[0] != card {
+			t.Errorf("expected the line of %v to start with it, got %v", card, solution.Line[0])
+		}
+		if solution.Points[team]-solution.Points[team.GetOpposingTeam()] > best.Points[team]-best.Points[team.GetOpposingTeam()] {
+			t.Errorf("expected %v not to beat the solved line", card)
+		}
+	}
+}
+
+func TestSolveRejectsHandNotInProgress(t *testing.T) {
+	hand := newDealtHand(t, 1, game.DefaultRuleSet())
+	for hand.GetState() == game.HandInProgress {
+		playFirstLegalCard(t, hand)
+	}
+	if _, err := Solve(hand); err == nil {
+		t.Errorf("expected a finished hand to be rejected")
+	}
+}
+
+func BenchmarkSolveFullDeal(b *testing.B) {
+	hands := make([]*game.Hand, 10)
+	for i := range hands {
+		hands[i] = newDealtHand(b, uint64(i), game.DefaultRuleSet())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Solve(hands[i%len(hands)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// DefaultTrumpSamples is a number of deals giving estimates steady enough to
+// rank the suits of most hands. Each deal is solved once per suit, which takes
+// a tenth of a second or so.
+const DefaultTrumpSamples = 8
+
+// TrumpEstimate is what a player can expect from a hand with Trump as trump
+// suit, should they take it.
+type TrumpEstimate struct {
+	Trump game.Suit `json:"trump"`
+
+	// Points and OpponentPoints are the average hand points of the player's
+	// team and of the other team once the contract rules are applied. Card
+	// points, declarations, Belote, the last trick bonus and capot count;
+	// contra does not.
+	Points         float64 `json:"points"`
+	OpponentPoints float64 `json:"opponentPoints"`
+
+	// MakeRate is the share of deals in which the player's team made the
+	// contract.
+	MakeRate float64 `json:"makeRate"`
+}
+
+// EvaluateTrumps estimates, for every suit, how the hand goes should the
+// player of view take the trump in that suit. It only uses what the player
+// sees while the trump is chosen: their first cards, the table trump card and
+// their seat relative to the starting player. Note that the table trump suit
+// cannot be named in the free trump selection.
+//
+// The cards left unseen are dealt at random samples times, the same deals
+// for every suit, and each deal is played out with every card known, as
+// Solve does. Perfect play makes the estimates optimistic for both teams:
+// they are best used to compare suits with each other. The suits are
+// evaluated in parallel.
+func EvaluateTrumps(view game.PlayerView, samples int, seed uint64) ([]TrumpEstimate, error) {
+	if view.State != game.TableTrumpSelection && view.State != game.FreeTrumpSelection {
+		return nil, fmt.Errorf("cannot evaluate trumps in state %s", view.State)
+	}
+	if err := checkTable(view.Rules); err != nil {
+		return nil, err
+	}
+	if len(view.Cards) != game.NUM_CARDS_BEFORE_TRUMP || view.TableTrumpCard == (game.Card{}) {
+		return nil, fmt.Errorf("expected %d cards and a table trump card", game.NUM_CARDS_BEFORE_TRUMP)
+	}
+	if samples <= 0 {
+		samples = DefaultTrumpSamples
+	}
+
+	evaluators := make([]*trumpEvaluator, len(solverSuits))
+	for i, suit := range solverSuits {
+		evaluators[i] = newTrumpEvaluator(suit, view)
+	}
+
+	r := rand.New(rand.NewPCG(seed, seed))
+	deals := make([]map[game.PlayerId][]game.Card, samples)
+	for i := range deals {
+		deals[i] = dealTrumpSample(view, r)
+	}
+
+	var wg sync.WaitGroup
+	for _, evaluator := range evaluators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, deal := range deals {
+				evaluator.add(deal)
+			}
+		}()
+	}
+	wg.Wait()
+
+	estimates := make([]TrumpEstimate, len(evaluators))
+	for i, evaluator := range evaluators {
+		estimates[i] = evaluator.estimate(samples)
+	}
+	return estimates, nil
+}
+
+// dealTrumpSample deals the cards unseen by the player of view, who takes the
+// table trump card and completes their hand like any taker.
+func dealTrumpSample(view game.PlayerView, r *rand.Rand) map[game.PlayerId][]game.Card {
+	seen := game.NewCardSet(view.Cards...).With(view.TableTrumpCard)
+	var unseen []game.Card
+	for _, card := range game.NewDeck() {
+		if !seen.Contains(card) {
+			unseen = append(unseen, card)
+		}
+	}
+	r.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+
+	deal := make(map[game.PlayerId][]game.Card, game.NUM_PLAYERS)
+	for player := game.Player1; player <= game.Player4; player++ {
+		if player == view.Player {
+			deal[player] = append(slices.Clone(view.Cards), view.TableTrumpCard)
+		}
+		n := game.NUM_CARDS_PER_PLAYER - len(deal[player])
+		deal[player] = append(deal[player], unseen[:n]...)
+		unseen = unseen[n:]
+	}
+	return deal
+}
+
+// trumpEvaluator sums up the outcome of the deals it is given with one trump.
+type trumpEvaluator struct {
+	trump          game.Suit
+	taker          game.PlayerId
+	startingPlayer game.PlayerId
+	rules          game.RuleSet
+	solver         *solver
+
+	points, opponentPoints int
+	made                   int
+}
+
+func newTrumpEvaluator(trump game.Suit, view game.PlayerView) *trumpEvaluator {
+	return &trumpEvaluator{
+		trump:          trump,
+		taker:          view.Player,
+		startingPlayer: view.StartingPlayer,
+		rules:          view.Rules,
+		solver:         newSolver(game.TrumpContract(trump), view.Rules),
+	}
+}
+
+func (e *trumpEvaluator) add(deal map[game.PlayerId][]game.Card) {
+	e.solver.reset(deal, e.startingPlayer)
+	value := e.solver.value()
+
+	totals := map[game.TeamId]int{
+		game.Team1: e.solver.teamPoints(value, 0),
+		game.Team2: e.solver.teamPoints(value, 1),
+	}
+	e.scoreDeclarations(deal, totals)
+
+	takers := e.taker.GetTeam()
+	defenders := takers.GetOpposingTeam()
+	switch {
+	case !e.rules.InsideRule || totals[takers] > totals[defenders]:
+		e.made++
+	case totals[takers] < totals[defenders]:
+		totals[defenders] += totals[takers]
+		totals[takers] = 0
+	default:
+		// The takers' points hang, to be won back in a later hand.
+		totals[takers] = 0
+	}
+
+	e.points += totals[takers]
+	e.opponentPoints += totals[defenders]
+}
+
+// scoreDeclarations adds the declarations of the deal and Belote to totals,
+// as if every player announced all they hold.
+func (e *trumpEvaluator) scoreDeclarations(deal map[game.PlayerId][]game.Card, totals map[game.TeamId]int) {
+	declarations := make(map[game.PlayerId][]game.Declaration, len(deal))
+	for player, cards := range deal {
+		for _, d := range e.rules.FindPreHandDeclarations(cards) {
+			declarations[player] = append(declarations[player], d)
+		}
+		if game.HasBelote(cards, e.trump) {
+			declarations[player] = append(declarations[player], game.Belote{})
+		}
+	}
+
+	winner := game.DeclarationWinner(declarations, e.startingPlayer, game.TrumpContract(e.trump), game.FourPlayerTable)
+	for player, decls := range declarations {
+		team := player.GetTeam()
+		won := winner != nil && *winner == team
+		for _, decl := range decls {
+			_, belote := decl.(game.Belote)
+			if won || (belote && e.rules.BeloteCountsWithoutDeclarationWin) {
+				totals[team] += e.rules.DeclarationValue(decl)
+			}
+		}
+	}
+}
+
+func (e *trumpEvaluator) estimate(samples int) TrumpEstimate {
+	return TrumpEstimate{
+		Trump:          e.trump,
+		Points:         float64(e.points) / float64(samples),
+		OpponentPoints: float64(e.opponentPoints) / float64(samples),
+		MakeRate:       float64(e.made) / float64(samples),
+	}
+}
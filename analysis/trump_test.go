@@ -0,0 +1,78 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+func newTrumpView(cards []game.Card, tableTrumpCard game.Card) game.PlayerView {
+	return game.PlayerView{
+		Player:         game.Player2,
+		StartingPlayer: game.Player1,
+		State:          game.TableTrumpSelection,
+		Cards:          cards,
+		TableTrumpCard: tableTrumpCard,
+		Rules:          game.DefaultRuleSet(),
+	}
+}
+
+func TestEvaluateTrumpsPrefersStrongTrumps(t *testing.T) {
+	view := newTrumpView([]game.Card{
+		{Suit: game.Hearts, Rank: game.Jack},
+		{Suit: game.Hearts, Rank: game.Nine},
+		{Suit: game.Hearts, Rank: game.Ace},
+		{Suit: game.Spades, Rank: game.Ace},
+		{Suit: game.Clubs, Rank: game.Seven},
+	}, game.Card{Suit: game.Hearts, Rank: game.Ten})
+
+	estimates, err := EvaluateTrumps(view, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(estimates) != 4 {
+		t.Fatalf("expected an estimate for every suit, got %d", len(estimates))
+	}
+
+	best := slices.MaxFunc(estimates, func(e1, e2 TrumpEstimate) int {
+		return int(e1.Points - e2.Points)
+	})
+	if best.Trump != game.Hearts {
+		t.Errorf("expected Hearts to be the best trump, got %+v", estimates)
+	}
+	if best.MakeRate < 0.9 || best.Points <= best.OpponentPoints {
+		t.Errorf("expected the contract to be made in Hearts, got %+v", best)
+	}
+	for _, estimate := range estimates {
+		if estimate.MakeRate < 0 || estimate.MakeRate > 1 {
+			t.Errorf("expected a make rate between 0 and 1, got %+v", estimate)
+		}
+	}
+}
+
+func TestEvaluateTrumpsIsDeterministicWithSeed(t *testing.T) {
+	view := newTrumpView([]game.Card{
+		{Suit: game.Spades, Rank: game.Jack},
+		{Suit: game.Diamonds, Rank: game.Nine},
+		{Suit: game.Hearts, Rank: game.King},
+		{Suit: game.Clubs, Rank: game.Ace},
+		{Suit: game.Clubs, Rank: game.Ten},
+	}, game.Card{Suit: game.Diamonds, Rank: game.Seven})
+
+	first, err := EvaluateTrumps(view, 2, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := EvaluateTrumps(view, 2, 7)
+	if !slices.Equal(first, second) {
+		t.Errorf("expected the same estimates from the same seed, got %v and %v", first, second)
+	}
+}
+
+func TestEvaluateTrumpsRejectsHandInProgress(t *testing.T) {
+	hand := newDealtHand(t, 1, game.DefaultRuleSet())
+	if _, err := EvaluateTrumps(hand.GetPlayerView(game.Player1), 1, 1); err == nil {
+		t.Errorf("expected a hand in progress to be rejected")
+	}
+}
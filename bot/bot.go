@@ -0,0 +1,18 @@
+// Package bot implements computer players. Bots only decide from a
+// game.PlayerView, so they never see more than a human in their seat would.
+package bot
+
+import (
+	"errors"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// Player chooses one of the legal actions of a view.
+type Player interface {
+	ChooseAction(view game.PlayerView) (game.Action, error)
+}
+
+var (
+	ErrNoLegalAction = errors.New("bot: no legal action")
+)
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// cardMemory holds what a seat knows about where the cards are.
+type cardMemory struct {
+	contract game.Contract
+	own      game.CardSet
+	played   game.CardSet
+}
+
+func newCardMemory(view game.PlayerView) cardMemory {
+	memory := cardMemory{
+		contract: view.Trump,
+		own:      game.NewCardSet(view.Cards...),
+	}
+
+	for _, trick := range view.CompletedTricks {
+		for _, card := range trick.Cards {
+			memory.played = memory.played.With(card)
+		}
+	}
+	if view.CurrentTrick != nil {
+		for _, card := range view.CurrentTrick.Cards {
+			memory.played = memory.played.With(card)
+		}
+	}
+
+	return memory
+}
+
+// outstanding reports whether card may still be held by another player.
+func (m cardMemory) outstanding(card game.Card) bool {
+	return !m.own.Contains(card) && !m.played.Contains(card)
+}
+
+// outstandingTrumps counts the cards of the trump suit other players may hold.
+// Contracts without a trump suit have none.
+func (m cardMemory) outstandingTrumps() int {
+	trump, ok := m.contract.TrumpSuit()
+	if !ok {
+		return 0
+	}
+	return game.SuitCards(trump).Difference(m.own).Difference(m.played).Len()
+}
+
+// isMaster reports whether no card held by another player beats card in its suit.
+func (m cardMemory) isMaster(card game.Card) bool {
+	isTrump := m.contract.IsTrump(card.Suit)
+	for _, other := range game.NewDeck() {
+		if other.Suit == card.Suit && m.outstanding(other) && game.Less(card.Rank, other.Rank, isTrump) {
+			return false
+		}
+	}
+	return true
+}
+
+// cheaper orders cards by the points they are worth, then by trick order.
+func cheaper(c1, c2 game.Card, contract game.Contract) bool {
+	p1, p2 := contract.CardPoints(c1), contract.CardPoints(c2)
+	if p1 != p2 {
+		return p1 < p2
+	}
+	return contract.TrickOrder(c1) < contract.TrickOrder(c2)
+}
+
+func cheapest(cards []game.Card, contract game.Contract) game.Card {
+	best := cards[0]
+	for _, card := range cards[1:] {
+		if cheaper(card, best, contract) {
+			best = card
+		}
+	}
+	return best
+}
+
+func mostValuable(cards []game.Card, contract game.Contract) game.Card {
+	best := cards[0]
+	for _, card := range cards[1:] {
+		if cheaper(best, card, contract) {
+			best = card
+		}
+	}
+	return best
+}
+
+// isTrumpSuit reports whether card is of the trump suit of contract, the suit
+// that takes tricks of any other.
+func isTrumpSuit(card game.Card, contract game.Contract) bool {
+	trump, ok := contract.TrumpSuit()
+	return ok && card.Suit == trump
+}
+
+func filterCards(cards []game.Card, keep func(game.Card) bool) []game.Card {
+	var result []game.Card
+	for _, card := range cards {
+		if keep(card) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func hasCard(cards []game.Card, card game.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
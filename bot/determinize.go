@@ -0,0 +1,249 @@
+package bot
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+const maxDealAttempts = 20
+
+// cardInference holds what a seat can work out about the cards it cannot see:
+// how many each other player holds, the cards known to be in a given hand and
+// the cards a player showed they do not have by how they followed. The cards
+// no player holds, left out of the hand or in the talon, go to NoPlayerId.
+type cardInference struct {
+	unseen    []game.Card
+	counts    map[game.PlayerId]int
+	known     map[game.PlayerId][]game.Card
+	forbidden map[game.PlayerId]game.CardSet
+}
+
+func newCardInference(view game.PlayerView) cardInference {
+	memory := newCardMemory(view)
+	inference := cardInference{
+		counts:    map[game.PlayerId]int{},
+		known:     map[game.PlayerId][]game.Card{},
+		forbidden: map[game.PlayerId]game.CardSet{},
+	}
+
+	// Every player holds as many cards as the others, but for the one they
+	// already put on the current trick.
+	held := len(view.Cards)
+	if _, ok := currentTrickCards(view)[view.Player]; ok {
+		held++
+	}
+	for _, player := range view.Rules.Table().PlayerIds() {
+		if player == view.Player {
+			continue
+		}
+		inference.counts[player] = held
+		if _, ok := currentTrickCards(view)[player]; ok {
+			inference.counts[player]--
+		}
+	}
+
+	tricks := slices.Clone(view.CompletedTricks)
+	if view.CurrentTrick != nil {
+		tricks = append(tricks, view.CurrentTrick)
+	}
+	for _, trick := range tricks {
+		inference.observeTrick(trick, view.Player)
+	}
+
+	for _, card := range game.NewDeck() {
+		if memory.outstanding(card) {
+			inference.unseen = append(inference.unseen, card)
+		}
+	}
+	undealt := len(inference.unseen)
+	for _, count := range inference.counts {
+		undealt -= count
+	}
+	if undealt > 0 {
+		inference.counts[game.NoPlayerId] = undealt
+	}
+
+	// The taker picked up the table trump card, a Belote announces the other
+	// trump honour and the winning declarations were shown to everyone.
+	if view.TableTrumpCard != (game.Card{}) && view.Taker != view.Player && memory.outstanding(view.TableTrumpCard) {
+		inference.addKnown(view.Taker, view.TableTrumpCard)
+	}
+	// Under all trumps, a Belote does not tell its suit.
+	trump, hasTrump := view.Trump.TrumpSuit()
+	for player, decls := range view.Declarations {
+		if player == view.Player || !hasTrump || !slices.ContainsFunc(decls, isBelote) {
+			continue
+		}
+		for _, rank := range []game.Rank{game.King, game.Queen} {
+			if card := (game.Card{Suit: trump, Rank: rank}); memory.outstanding(card) {
+				inference.addKnown(player, card)
+			}
+		}
+	}
+
+	for player, cards := range view.RevealedCards {
+		if player == view.Player {
+			continue
+		}
+		for _, card := range cards.Cards() {
+			if memory.outstanding(card) {
+				inference.addKnown(player, card)
+			}
+		}
+	}
+
+	return inference
+}
+
+func currentTrickCards(view game.PlayerView) map[game.PlayerId]game.Card {
+	if view.CurrentTrick == nil {
+		return nil
+	}
+	return view.CurrentTrick.Cards
+}
+
+func isBelote(d game.Declaration) bool {
+	_, ok := d.(game.Belote)
+	return ok
+}
+
+func (i *cardInference) addKnown(player game.PlayerId, card game.Card) {
+	if !slices.Contains(i.known[player], card) {
+		i.known[player] = append(i.known[player], card)
+	}
+}
+
+// observeTrick replays trick in seat order and records the suits players
+// revealed they are out of, following the rules Trick.PlayCard enforces.
+func (i *cardInference) observeTrick(trick *game.Trick, self game.PlayerId) {
+	partial := game.NewTrick(trick.StartingPlayer, trick.Trump, trick.Table)
+	player := trick.StartingPlayer
+	for range len(trick.Cards) {
+		card := trick.Cards[player]
+		if player != self && len(partial.Cards) > 0 {
+			i.observeFollow(partial, player, card)
+		}
+		partial.Cards[player] = card
+		player = trick.Table.Next(player)
+	}
+}
+
+func (i *cardInference) observeFollow(partial *game.Trick, player game.PlayerId, card game.Card) {
+	trump, hasTrump := partial.Trump.TrumpSuit()
+	leadSuit := partial.Cards[partial.StartingPlayer].Suit
+
+	if card.Suit != leadSuit {
+		i.forbidSuit(player, leadSuit)
+		if !hasTrump {
+			return
+		}
+		if card.Suit != trump {
+			i.forbidSuit(player, trump)
+			return
+		}
+	}
+	if !partial.Trump.IsTrump(card.Suit) {
+		return
+	}
+
+	// A player who had to trump and stayed under the highest trump on the
+	// table holds no higher trump.
+	var highest *game.Rank
+	for _, played := range partial.Cards {
+		if played.Suit == card.Suit && (highest == nil || game.Less(*highest, played.Rank, true)) {
+			rank := played.Rank
+			highest = &rank
+		}
+	}
+	if highest == nil || !game.Less(card.Rank, *highest, true) {
+		return
+	}
+	for _, other := range game.SuitCards(card.Suit).Cards() {
+		if game.Less(*highest, other.Rank, true) {
+			i.forbidden[player] = i.forbidden[player].With(other)
+		}
+	}
+}
+
+func (i *cardInference) forbidSuit(player game.PlayerId, suit game.Suit) {
+	i.forbidden[player] = i.forbidden[player].Union(game.SuitCards(suit))
+}
+
+// deal hands the unseen cards out at random, consistently with everything
+// inferred. Should the inferences contradict each other, the suits revealed
+// by play are ignored rather than failing.
+func (i *cardInference) deal(r *rand.Rand) map[game.PlayerId][]game.Card {
+	for range maxDealAttempts {
+		if hands, ok := i.tryDeal(r, true); ok {
+			return hands
+		}
+	}
+	hands, _ := i.tryDeal(r, false)
+	return hands
+}
+
+func (i *cardInference) tryDeal(r *rand.Rand, useForbidden bool) (map[game.PlayerId][]game.Card, bool) {
+	hands := make(map[game.PlayerId][]game.Card, len(i.counts))
+	needed := make(map[game.PlayerId]int, len(i.counts))
+	var assigned game.CardSet
+	for player, count := range i.counts {
+		for _, card := range i.known[player] {
+			if len(hands[player]) < count {
+				hands[player] = append(hands[player], card)
+				assigned = assigned.With(card)
+			}
+		}
+		needed[player] = count - len(hands[player])
+	}
+
+	allowed := func(player game.PlayerId, card game.Card) bool {
+		return needed[player] > 0 && (!useForbidden || !i.forbidden[player].Contains(card))
+	}
+
+	cards := filterCards(i.unseen, func(c game.Card) bool { return !assigned.Contains(c) })
+	r.Shuffle(len(cards), func(a, b int) { cards[a], cards[b] = cards[b], cards[a] })
+	// Deal the most constrained cards first.
+	slices.SortStableFunc(cards, func(c1, c2 game.Card) int {
+		return i.countAllowed(c1, allowed) - i.countAllowed(c2, allowed)
+	})
+
+	for _, card := range cards {
+		total := 0
+		for player := range needed {
+			if allowed(player, card) {
+				total += needed[player]
+			}
+		}
+		if total == 0 {
+			return nil, false
+		}
+
+		pick := r.IntN(total)
+		for _, player := range slices.Sorted(maps.Keys(needed)) {
+			if !allowed(player, card) {
+				continue
+			}
+			if pick < needed[player] {
+				hands[player] = append(hands[player], card)
+				needed[player]--
+				break
+			}
+			pick -= needed[player]
+		}
+	}
+
+	return hands, true
+}
+
+func (i *cardInference) countAllowed(card game.Card, allowed func(game.PlayerId, game.Card) bool) int {
+	count := 0
+	for player := range i.counts {
+		if allowed(player, card) {
+			count++
+		}
+	}
+	return count
+}
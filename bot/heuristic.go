@@ -0,0 +1,270 @@
+package bot
+
+import (
+	"slices"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// HeuristicPlayer is a rule-based bot: it takes trump or bids on hand
+// strength, leads trumps when its team took them, cashes master cards and
+// keeps its Aces and Tens away from tricks it cannot win.
+type HeuristicPlayer struct{}
+
+const (
+	takeTrumpThreshold = 60
+	contraThreshold    = 95
+	recontraThreshold  = 110
+
+	trumpLengthBonus = 10
+
+	// bidMargin is how far above the strength of its hand the bot bids.
+	bidMargin = 10
+)
+
+func NewHeuristicPlayer() *HeuristicPlayer {
+	return &HeuristicPlayer{}
+}
+
+func (b *HeuristicPlayer) ChooseAction(view game.PlayerView) (game.Action, error) {
+	if len(view.LegalActions) == 0 {
+		return game.Action{}, ErrNoLegalAction
+	}
+
+	switch view.LegalActions[0].Type {
+	case game.AcceptTableTrumpAction:
+		return game.Action{Type: game.AcceptTableTrumpAction, Accept: takeTableTrump(view)}, nil
+	case game.SelectTrumpAction:
+		return selectFreeTrump(view), nil
+	case game.BidAction:
+		return chooseBid(view), nil
+	case game.ContraAction:
+		return game.Action{Type: game.ContraAction, Accept: trumpStrength(view.Cards, view.Trump) >= contraThreshold}, nil
+	case game.RecontraAction:
+		return game.Action{Type: game.RecontraAction, Accept: trumpStrength(view.Cards, view.Trump) >= recontraThreshold}, nil
+	case game.PlayCardAction:
+		return game.Action{Type: game.PlayCardAction, Card: chooseCard(view)}, nil
+	case game.CutAction:
+		// Cut near the middle, like most players do.
+		return view.LegalActions[len(view.LegalActions)/2], nil
+	}
+
+	return view.LegalActions[0], nil
+}
+
+// trumpStrength estimates how well cards play in contract: trump card points
+// plus a bonus per card of the trump suit, and side Aces and protected Tens.
+func trumpStrength(cards []game.Card, contract game.Contract) int {
+	strength := 0
+	for _, card := range cards {
+		switch {
+		case isTrumpSuit(card, contract):
+			strength += card.Rank.GetTrumpPoints() + trumpLengthBonus
+		case contract.IsTrump(card.Suit):
+			strength += card.Rank.GetTrumpPoints()
+		case card.Rank == game.Ace:
+			strength += card.Rank.GetNonTrumpPoints()
+		case card.Rank == game.Ten && hasCard(cards, game.Card{Suit: card.Suit, Rank: game.Ace}):
+			strength += card.Rank.GetNonTrumpPoints()
+		}
+	}
+	return strength
+}
+
+// cardsWithTableTrump returns the cards the player would hold after taking the
+// table trump card.
+func cardsWithTableTrump(view game.PlayerView) []game.Card {
+	return append(append([]game.Card(nil), view.Cards...), view.TableTrumpCard)
+}
+
+func takeTableTrump(view game.PlayerView) bool {
+	return trumpStrength(cardsWithTableTrump(view), game.TrumpContract(view.TableTrumpCard.Suit)) >= takeTrumpThreshold
+}
+
+// selectFreeTrump picks the suit, or the no-trumps or all-trumps contract
+// where the rules allow them, its cards play best, and passes when none is
+// strong enough and it may.
+func selectFreeTrump(view game.PlayerView) game.Action {
+	cards := cardsWithTableTrump(view)
+
+	var best *game.Action
+	bestStrength := 0
+	canPass := false
+	for _, action := range view.LegalActions {
+		var contract game.Contract
+		switch {
+		case action.Type == game.SelectTrumpAction && action.Suit == nil:
+			canPass = true
+			continue
+		case action.Type == game.SelectTrumpAction:
+			contract = game.TrumpContract(*action.Suit)
+		case action.Type == game.SelectContractAction && action.Contract != nil:
+			contract = *action.Contract
+		default:
+			continue
+		}
+		if strength := trumpStrength(cards, contract); best == nil || strength > bestStrength {
+			best, bestStrength = &action, strength
+		}
+	}
+
+	if best == nil || canPass && bestStrength < takeTrumpThreshold {
+		return game.Action{Type: game.SelectTrumpAction, Suit: nil}
+	}
+	return *best
+}
+
+// chooseBid doubles an opponent's bid it is strong against in trumps, and
+// redoubles its partner's, like it answers a contra. Otherwise it bids the
+// cheapest it may in the contract its cards play best, up to what they are
+// worth, but never over its partner.
+func chooseBid(view game.PlayerView) game.Action {
+	pass := game.Action{Type: game.BidAction}
+	table := view.Rules.Table()
+	if len(view.Bids) > 0 {
+		last := view.Bids[len(view.Bids)-1]
+		strength := trumpStrength(view.Cards, last.Contract)
+		double := game.Action{Type: game.ContraAction, Accept: true}
+		redouble := game.Action{Type: game.RecontraAction, Accept: true}
+		switch {
+		case slices.Contains(view.LegalActions, redouble) && strength >= recontraThreshold:
+			return redouble
+		case table.Team(last.Player) == table.Team(view.Player):
+			return pass
+		case slices.Contains(view.LegalActions, double) && strength >= contraThreshold:
+			return double
+		}
+	}
+
+	var best *game.Bid
+	bestStrength := 0
+	for _, action := range view.LegalActions {
+		if action.Type != game.BidAction || action.Bid == nil {
+			continue
+		}
+		if strength := trumpStrength(view.Cards, action.Bid.Contract); best == nil || strength > bestStrength {
+			best, bestStrength = action.Bid, strength
+		}
+	}
+
+	// Bulgarian bids have no points: they are made on the strength that
+	// takes a trump.
+	limit := min((bestStrength+bidMargin)/game.BID_STEP*game.BID_STEP, game.MAX_BID)
+	if best == nil || best.Points > limit || (best.Points == 0 && bestStrength < takeTrumpThreshold) {
+		return pass
+	}
+	return game.Action{Type: game.BidAction, Bid: best}
+}
+
+func legalCards(view game.PlayerView) []game.Card {
+	var cards []game.Card
+	for _, action := range view.LegalActions {
+		if action.Type == game.PlayCardAction {
+			cards = append(cards, action.Card)
+		}
+	}
+	return cards
+}
+
+func chooseCard(view game.PlayerView) game.Card {
+	legal := legalCards(view)
+	if len(legal) == 1 {
+		return legal[0]
+	}
+
+	memory := newCardMemory(view)
+	if len(view.CurrentTrick.Cards) == 0 {
+		return chooseLead(view, legal, memory)
+	}
+	return chooseFollow(view, legal, memory)
+}
+
+func chooseLead(view game.PlayerView, legal []game.Card, memory cardMemory) game.Card {
+	trump := view.Trump
+	trumps := filterCards(legal, func(c game.Card) bool { return isTrumpSuit(c, trump) })
+	sideCards := filterCards(legal, func(c game.Card) bool { return !isTrumpSuit(c, trump) })
+
+	// Takers draw the opponents' trumps out.
+	table := view.Rules.Table()
+	if table.Team(view.Player) == table.Team(view.Taker) && len(trumps) > 0 && memory.outstandingTrumps() > 0 {
+		highest := highestCard(trumps, true)
+		if memory.isMaster(highest) {
+			return highest
+		}
+		if len(trumps) >= 3 {
+			return cheapest(trumps, trump)
+		}
+	}
+
+	if masters := filterCards(sideCards, memory.isMaster); len(masters) > 0 {
+		return mostValuable(masters, trump)
+	}
+
+	if len(sideCards) > 0 {
+		// Keep Aces and Tens for tricks they can win.
+		safe := filterCards(sideCards, func(c game.Card) bool { return c.Rank != game.Ace && c.Rank != game.Ten })
+		if len(safe) > 0 {
+			return cheapest(safe, trump)
+		}
+		return cheapest(sideCards, trump)
+	}
+
+	return cheapest(trumps, trump)
+}
+
+func chooseFollow(view game.PlayerView, legal []game.Card, memory cardMemory) game.Card {
+	trick := view.CurrentTrick
+	trump := view.Trump
+	isLast := len(trick.Cards) == trick.Table.Players()-1
+
+	winner, err := trick.GetCurrentWinner()
+	if err != nil {
+		return cheapest(legal, trump)
+	}
+
+	if winner == view.Rules.Table().Teammate(view.Player) {
+		winningCard := trick.Cards[winner]
+		if isLast || (memory.isMaster(winningCard) && winningCard.Suit == trick.Cards[trick.StartingPlayer].Suit) {
+			// The trick is ours: give partner the most points without
+			// wasting trumps.
+			if sideCards := filterCards(legal, func(c game.Card) bool { return !isTrumpSuit(c, trump) }); len(sideCards) > 0 {
+				return mostValuable(sideCards, trump)
+			}
+		}
+		return cheapest(legal, trump)
+	}
+
+	winning := filterCards(legal, func(c game.Card) bool { return wins(trick, view.Player, c) })
+	if len(winning) == 0 {
+		if sideCards := filterCards(legal, func(c game.Card) bool { return !isTrumpSuit(c, trump) }); len(sideCards) > 0 {
+			return cheapest(sideCards, trump)
+		}
+		return cheapest(legal, trump)
+	}
+
+	if isLast {
+		return mostValuable(winning, trump)
+	}
+	if masters := filterCards(winning, memory.isMaster); len(masters) > 0 {
+		return cheapest(masters, trump)
+	}
+	return cheapest(winning, trump)
+}
+
+// wins reports whether card would currently take trick if player played it.
+func wins(trick *game.Trick, player game.PlayerId, card game.Card) bool {
+	next := trick.Clone()
+	next.Cards[player] = card
+	winner, err := next.GetCurrentWinner()
+	return err == nil && winner == player
+}
+
+func highestCard(cards []game.Card, isTrump bool) game.Card {
+	best := cards[0]
+	for _, card := range cards[1:] {
+		if game.Less(best.Rank, card.Rank, isTrump) {
+			best = card
+		}
+	}
+	return best
+}
@@ -0,0 +1,291 @@
+package bot
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// playBotGame plays a whole game with one bot per seat and fails on any
+// illegal or rejected action.
+func playBotGame(t *testing.T, gm *game.BeloteGame, players map[game.PlayerId]Player) {
+	t.Helper()
+
+	gm.Start()
+	for moves := 0; gm.GetState() != game.GameFinished; moves++ {
+		if moves > 10000 {
+			t.Fatal("game did not finish")
+		}
+
+		for player := game.Player1; player <= game.Player4; player++ {
+			view := gm.GetPlayerView(player)
+			if len(view.LegalActions) == 0 {
+				continue
+			}
+
+			action, err := players[player].ChooseAction(view)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.ContainsFunc(view.LegalActions, func(a game.Action) bool { return sameAction(a, action) }) {
+				t.Fatalf("player %d chose %+v, which is not legal", player, action)
+			}
+			if err := gm.PlayAction(player, action); err != nil {
+				t.Fatalf("player %d chose %+v: %v", player, action, err)
+			}
+			break
+		}
+	}
+}
+
+func sameAction(a1, a2 game.Action) bool {
+	if (a1.Suit == nil) != (a2.Suit == nil) || (a1.Suit != nil && *a1.Suit != *a2.Suit) {
+		return false
+	}
+	if (a1.Contract == nil) != (a2.Contract == nil) || (a1.Contract != nil && *a1.Contract != *a2.Contract) {
+		return false
+	}
+	return a1.Type == a2.Type && a1.Accept == a2.Accept && a1.Card == a2.Card
+}
+
+func TestHeuristicBotsPlayWholeGames(t *testing.T) {
+	players := map[game.PlayerId]Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		players[player] = NewHeuristicPlayer()
+	}
+
+	for seed := uint64(0); seed < 10; seed++ {
+		gm := game.NewBeloteGameWithDealer(game.DefaultRuleSet(), game.NewSeededDealerFactory(seed))
+		playBotGame(t, &gm, players)
+	}
+}
+
+func TestHeuristicBotsPlayAuctions(t *testing.T) {
+	players := map[game.PlayerId]Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		players[player] = NewHeuristicPlayer()
+	}
+
+	for _, preset := range []string{game.CoincheRuleSetPreset, game.BulgarianRuleSetPreset} {
+		rules, _ := game.GetRuleSetPreset(preset)
+		for seed := uint64(0); seed < 5; seed++ {
+			gm := game.NewBeloteGameWithDealer(rules, game.NewSeededDealerFactory(seed))
+			playBotGame(t, &gm, players)
+		}
+	}
+}
+
+func TestHeuristicBotBidsOnStrength(t *testing.T) {
+	// Dealt in deck order, Player1 holds every Spade and Player2 every Heart.
+	rules, _ := game.GetRuleSetPreset(game.CoincheRuleSetPreset)
+	gm := game.NewBeloteGameWithDealer(rules, game.NewFixedDealerFactory(game.NewDeck()))
+	gm.Start()
+
+	bot := NewHeuristicPlayer()
+	action, _ := bot.ChooseAction(gm.GetPlayerView(game.Player1))
+	if action.Bid == nil || action.Bid.Contract != game.TrumpContract(game.Spades) || action.Bid.Points != game.MIN_BID {
+		t.Fatalf("expected the cheapest bid in Spades, got %+v", action)
+	}
+	if err := gm.PlayAction(game.Player1, action); err != nil {
+		t.Fatal(err)
+	}
+
+	action, _ = bot.ChooseAction(gm.GetPlayerView(game.Player2))
+	if action.Bid == nil || action.Bid.Contract != game.TrumpContract(game.Hearts) || action.Bid.Points != game.MIN_BID+game.BID_STEP {
+		t.Fatalf("expected an overbid in Hearts, got %+v", action)
+	}
+	if err := gm.PlayAction(game.Player2, action); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gm.Bid(game.Player3, nil); err != nil {
+		t.Fatal(err)
+	}
+	if action, _ := bot.ChooseAction(gm.GetPlayerView(game.Player4)); action.Type != game.BidAction || action.Bid != nil {
+		t.Errorf("expected the bot not to bid over its partner, got %+v", action)
+	}
+}
+
+func TestHeuristicBotWithoutLegalActions(t *testing.T) {
+	if _, err := NewHeuristicPlayer().ChooseAction(game.PlayerView{}); err != ErrNoLegalAction {
+		t.Errorf("expected ErrNoLegalAction, got %v", err)
+	}
+}
+
+func cardActions(cards ...game.Card) []game.Action {
+	var actions []game.Action
+	for _, card := range cards {
+		actions = append(actions, game.Action{Type: game.PlayCardAction, Card: card})
+	}
+	return actions
+}
+
+func TestHeuristicBotsPlayFreeContracts(t *testing.T) {
+	players := map[game.PlayerId]Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		players[player] = NewHeuristicPlayer()
+	}
+
+	rules, _ := game.GetRuleSetPreset(game.ContractsRuleSetPreset)
+	for seed := uint64(0); seed < 10; seed++ {
+		gm := game.NewBeloteGameWithDealer(rules, game.NewSeededDealerFactory(seed))
+		playBotGame(t, &gm, players)
+	}
+}
+
+func TestHeuristicBotSelectsATrumpWhenLast(t *testing.T) {
+	hearts, spades := game.Hearts, game.Spades
+	noTrumps, allTrumps := game.NoTrumpsContract, game.AllTrumpsContract
+	view := game.PlayerView{
+		Cards: []game.Card{
+			{Suit: game.Spades, Rank: game.Seven},
+			{Suit: game.Spades, Rank: game.Eight},
+			{Suit: game.Hearts, Rank: game.Nine},
+			{Suit: game.Clubs, Rank: game.Queen},
+			{Suit: game.Diamonds, Rank: game.Eight},
+		},
+		TableTrumpCard: game.Card{Suit: game.Clubs, Rank: game.Seven},
+		LegalActions: []game.Action{
+			{Type: game.SelectTrumpAction, Suit: &hearts},
+			{Type: game.SelectTrumpAction, Suit: &spades},
+			{Type: game.SelectContractAction, Contract: &noTrumps},
+			{Type: game.SelectContractAction, Contract: &allTrumps},
+		},
+	}
+
+	action, err := NewHeuristicPlayer().ChooseAction(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(view.LegalActions, func(a game.Action) bool { return sameAction(a, action) }) {
+		t.Errorf("expected a weak last player to choose a legal trump, got %+v", action)
+	}
+}
+
+func TestHeuristicBotTakesTrumpOnStrength(t *testing.T) {
+	tableTrumpActions := []game.Action{
+		{Type: game.AcceptTableTrumpAction, Accept: true},
+		{Type: game.AcceptTableTrumpAction, Accept: false},
+	}
+
+	strong := game.PlayerView{
+		Cards: []game.Card{
+			{Suit: game.Hearts, Rank: game.Jack},
+			{Suit: game.Hearts, Rank: game.Nine},
+			{Suit: game.Spades, Rank: game.Ace},
+			{Suit: game.Clubs, Rank: game.Seven},
+			{Suit: game.Diamonds, Rank: game.Eight},
+		},
+		TableTrumpCard: game.Card{Suit: game.Hearts, Rank: game.Ten},
+		LegalActions:   tableTrumpActions,
+	}
+	weak := game.PlayerView{
+		Cards: []game.Card{
+			{Suit: game.Spades, Rank: game.Seven},
+			{Suit: game.Spades, Rank: game.Nine},
+			{Suit: game.Clubs, Rank: game.Queen},
+			{Suit: game.Clubs, Rank: game.Seven},
+			{Suit: game.Diamonds, Rank: game.Eight},
+		},
+		TableTrumpCard: game.Card{Suit: game.Hearts, Rank: game.Seven},
+		LegalActions:   tableTrumpActions,
+	}
+
+	bot := NewHeuristicPlayer()
+	if action, _ := bot.ChooseAction(strong); !action.Accept {
+		t.Errorf("expected a strong hand to take the trump")
+	}
+	if action, _ := bot.ChooseAction(weak); action.Accept {
+		t.Errorf("expected a weak hand to pass")
+	}
+}
+
+func TestHeuristicBotTakerLeadsMasterTrump(t *testing.T) {
+	cards := []game.Card{
+		{Suit: game.Hearts, Rank: game.Jack},
+		{Suit: game.Hearts, Rank: game.Seven},
+		{Suit: game.Spades, Rank: game.Ace},
+	}
+	view := game.PlayerView{
+		Player:       game.Player1,
+		State:        game.HandInProgress,
+		Cards:        cards,
+		Trump:        game.TrumpContract(game.Hearts),
+		Taker:        game.Player3,
+		CurrentTrick: game.NewTrick(game.Player1, game.TrumpContract(game.Hearts), game.FourPlayerTable),
+		LegalActions: cardActions(cards...),
+	}
+
+	action, err := NewHeuristicPlayer().ChooseAction(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Card != (game.Card{Suit: game.Hearts, Rank: game.Jack}) {
+		t.Errorf("expected the taker to lead the Jack of trumps, got %v", action.Card)
+	}
+
+	view.Taker = game.Player2
+	action, _ = NewHeuristicPlayer().ChooseAction(view)
+	if action.Card != (game.Card{Suit: game.Spades, Rank: game.Ace}) {
+		t.Errorf("expected a defender to cash the Ace, got %v", action.Card)
+	}
+}
+
+func TestHeuristicBotGivesPointsToWinningPartner(t *testing.T) {
+	cards := []game.Card{
+		{Suit: game.Clubs, Rank: game.Ten},
+		{Suit: game.Clubs, Rank: game.Seven},
+	}
+	trick := game.NewTrick(game.Player1, game.TrumpContract(game.Hearts), game.FourPlayerTable)
+	trick.Cards[game.Player1] = game.Card{Suit: game.Clubs, Rank: game.Eight}
+	trick.Cards[game.Player2] = game.Card{Suit: game.Clubs, Rank: game.Ace}
+	trick.Cards[game.Player3] = game.Card{Suit: game.Hearts, Rank: game.Seven}
+
+	view := game.PlayerView{
+		Player:       game.Player4,
+		Cards:        cards,
+		Trump:        game.TrumpContract(game.Hearts),
+		Taker:        game.Player1,
+		CurrentTrick: trick,
+		LegalActions: cardActions(cards...),
+	}
+
+	action, _ := NewHeuristicPlayer().ChooseAction(view)
+	if action.Card != (game.Card{Suit: game.Clubs, Rank: game.Seven}) {
+		t.Errorf("expected to keep the Ten when opponents take the trick, got %v", action.Card)
+	}
+
+	trick.Cards[game.Player3] = game.Card{Suit: game.Clubs, Rank: game.Nine}
+
+	action, _ = NewHeuristicPlayer().ChooseAction(view)
+	if action.Card != (game.Card{Suit: game.Clubs, Rank: game.Ten}) {
+		t.Errorf("expected to give the Ten to the winning partner, got %v", action.Card)
+	}
+}
+
+func TestHeuristicBotWinsCheaplyWhenLast(t *testing.T) {
+	cards := []game.Card{
+		{Suit: game.Clubs, Rank: game.Ace},
+		{Suit: game.Clubs, Rank: game.Queen},
+		{Suit: game.Clubs, Rank: game.Seven},
+	}
+	trick := game.NewTrick(game.Player1, game.TrumpContract(game.Hearts), game.FourPlayerTable)
+	trick.Cards[game.Player1] = game.Card{Suit: game.Clubs, Rank: game.Jack}
+	trick.Cards[game.Player2] = game.Card{Suit: game.Clubs, Rank: game.Eight}
+	trick.Cards[game.Player3] = game.Card{Suit: game.Clubs, Rank: game.Nine}
+
+	view := game.PlayerView{
+		Player:       game.Player4,
+		Cards:        cards,
+		Trump:        game.TrumpContract(game.Hearts),
+		Taker:        game.Player1,
+		CurrentTrick: trick,
+		LegalActions: cardActions(cards...),
+	}
+
+	action, _ := NewHeuristicPlayer().ChooseAction(view)
+	if action.Card != (game.Card{Suit: game.Clubs, Rank: game.Ace}) {
+		t.Errorf("expected to take the trick with the most points when last, got %v", action.Card)
+	}
+}
@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// SearchBudget bounds the search an ISMCTSPlayer runs for each card. The
+// search stops at whichever limit is reached first; a zero field is no limit.
+type SearchBudget struct {
+	Iterations int
+	Duration   time.Duration
+}
+
+var (
+	NormalSearchBudget = SearchBudget{Iterations: 300}
+	HardSearchBudget   = SearchBudget{Iterations: 3000, Duration: time.Second}
+)
+
+const (
+	defaultIterations = 1000
+	explorationWeight = 0.7
+)
+
+// ISMCTSPlayer plays cards with information set Monte Carlo tree search. Each
+// iteration deals the unseen cards at random, consistently with what its seat
+// has seen, and plays the deal out while growing a single tree shared by all
+// deals. Trump and doubling decisions are left to a HeuristicPlayer.
+//
+// An ISMCTSPlayer is not safe for concurrent use.
+type ISMCTSPlayer struct {
+	budget    SearchBudget
+	rand      *rand.Rand
+	heuristic *HeuristicPlayer
+}
+
+func NewISMCTSPlayer(budget SearchBudget) *ISMCTSPlayer {
+	return NewSeededISMCTSPlayer(budget, rand.Uint64())
+}
+
+func NewSeededISMCTSPlayer(budget SearchBudget, seed uint64) *ISMCTSPlayer {
+	return &ISMCTSPlayer{
+		budget:    budget,
+		rand:      rand.New(rand.NewPCG(seed, seed)),
+		heuristic: NewHeuristicPlayer(),
+	}
+}
+
+func (b *ISMCTSPlayer) ChooseAction(view game.PlayerView) (game.Action, error) {
+	if len(view.LegalActions) == 0 {
+		return game.Action{}, ErrNoLegalAction
+	}
+
+	// Cards left in the talon cannot be searched: nobody knows the order
+	// they will be drawn in.
+	legal := legalCards(view)
+	if view.State != game.HandInProgress || len(legal) == 0 || view.Talon > 0 {
+		return b.heuristic.ChooseAction(view)
+	}
+	if len(legal) == 1 {
+		return game.Action{Type: game.PlayCardAction, Card: legal[0]}, nil
+	}
+
+	card, err := b.search(view)
+	if err != nil {
+		return game.Action{}, err
+	}
+	return game.Action{Type: game.PlayCardAction, Card: card}, nil
+}
+
+// searchNode is a card played from the position of its parent. Availability
+// counts the iterations in which the card could be played at all, as it
+// depends on the deal.
+type searchNode struct {
+	card         game.Card
+	player       game.PlayerId
+	parent       *searchNode
+	children     []*searchNode
+	visits       int
+	availability int
+	reward       float64
+}
+
+func (b *ISMCTSPlayer) search(view game.PlayerView) (game.Card, error) {
+	base, err := game.NewHandFromView(view)
+	if err != nil {
+		return game.Card{}, err
+	}
+	inference := newCardInference(view)
+
+	iterations := b.budget.Iterations
+	if iterations == 0 && b.budget.Duration == 0 {
+		iterations = defaultIterations
+	}
+	deadline := time.Now().Add(b.budget.Duration)
+
+	// The first iteration always runs, so the root has a card to return
+	// however short the budget.
+	root := &searchNode{}
+	for i := 0; iterations == 0 || i < iterations; i++ {
+		if i > 0 && b.budget.Duration > 0 && time.Now().After(deadline) {
+			break
+		}
+
+		hand := base.Clone()
+		for player, cards := range inference.deal(b.rand) {
+			if player != game.NoPlayerId {
+				hand.PlayerCards[player] = game.NewCardSet(cards...)
+			}
+		}
+		b.iterate(root, hand)
+	}
+
+	best := root.children[0]
+	for _, child := range root.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.card, nil
+}
+
+// iterate walks the tree down with the deal in hand, adds one card to it,
+// plays the rest of the hand out at random and scores every card on the way.
+func (b *ISMCTSPlayer) iterate(root *searchNode, hand *game.Hand) {
+	node := root
+	for hand.GetState() == game.HandInProgress {
+		player, _ := hand.GetCurrentTurn()
+		legal := hand.LegalCards(player)
+
+		var untried []game.Card
+		for _, card := range legal {
+			if child := node.child(card); child != nil {
+				child.availability++
+			} else {
+				untried = append(untried, card)
+			}
+		}
+
+		if len(untried) > 0 {
+			child := &searchNode{card: untried[b.rand.IntN(len(untried))], player: player, parent: node, availability: 1}
+			node.children = append(node.children, child)
+			node = child
+			b.playCard(hand, player, node.card)
+			break
+		}
+
+		node = node.selectChild(legal)
+		b.playCard(hand, player, node.card)
+	}
+
+	for hand.GetState() == game.HandInProgress {
+		player, _ := hand.GetCurrentTurn()
+		legal := hand.LegalCards(player)
+		b.playCard(hand, player, legal[b.rand.IntN(len(legal))])
+	}
+
+	table := hand.GetRules().Table()
+	for ; node != nil; node = node.parent {
+		node.visits++
+		if node.parent != nil {
+			node.reward += teamShare(hand, table.Team(node.player))
+		}
+	}
+}
+
+func (b *ISMCTSPlayer) playCard(hand *game.Hand, player game.PlayerId, card game.Card) {
+	if err := hand.PlayCard(player, card, game.Announcement{}); err != nil {
+		panic(err)
+	}
+}
+
+func (n *searchNode) child(card game.Card) *searchNode {
+	for _, child := range n.children {
+		if child.card == card {
+			return child
+		}
+	}
+	return nil
+}
+
+// selectChild picks the legal child with the best upper confidence bound.
+func (n *searchNode) selectChild(legal []game.Card) *searchNode {
+	var best *searchNode
+	bestScore := math.Inf(-1)
+	for _, card := range legal {
+		child := n.child(card)
+		score := child.reward/float64(child.visits) +
+			explorationWeight*math.Sqrt(math.Log(float64(child.availability))/float64(child.visits))
+		if score > bestScore {
+			best, bestScore = child, score
+		}
+	}
+	return best
+}
+
+// teamShare returns the share of the points of a finished hand that went to team.
+func teamShare(hand *game.Hand, team game.TeamId) float64 {
+	totals := hand.GetTotals()
+	sum := 0
+	for _, total := range totals {
+		sum += total
+	}
+	if sum == 0 {
+		return 1 / float64(len(totals))
+	}
+	return float64(totals[team]) / float64(sum)
+}
@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+func TestISMCTSBotsPlayWholeGames(t *testing.T) {
+	players := map[game.PlayerId]Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		if player.GetTeam() == game.Team1 {
+			players[player] = NewSeededISMCTSPlayer(SearchBudget{Iterations: 50}, uint64(player))
+		} else {
+			players[player] = NewHeuristicPlayer()
+		}
+	}
+
+	for seed := uint64(0); seed < 3; seed++ {
+		gm := game.NewBeloteGameWithDealer(game.DefaultRuleSet(), game.NewSeededDealerFactory(seed))
+		playBotGame(t, &gm, players)
+	}
+}
+
+func TestISMCTSBotsPlaySmallTables(t *testing.T) {
+	for _, preset := range []string{game.TwoPlayerRuleSetPreset, game.ThreePlayerRuleSetPreset} {
+		rules, _ := game.GetRuleSetPreset(preset)
+		rules.TargetScore = 300
+
+		players := map[game.PlayerId]Player{}
+		for _, player := range rules.Table().PlayerIds() {
+			players[player] = NewSeededISMCTSPlayer(SearchBudget{Iterations: 30}, uint64(player))
+		}
+		gm := game.NewBeloteGameWithDealer(rules, game.NewSeededDealerFactory(1))
+		playBotGame(t, &gm, players)
+	}
+}
+
+func TestISMCTSBotsPlayWithAnExpiredBudget(t *testing.T) {
+	players := map[game.PlayerId]Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		players[player] = NewSeededISMCTSPlayer(SearchBudget{Duration: time.Nanosecond}, uint64(player))
+	}
+
+	gm := game.NewBeloteGameWithDealer(game.DefaultRuleSet(), game.NewSeededDealerFactory(0))
+	playBotGame(t, &gm, players)
+}
+
+func TestISMCTSRewardsTheSeatAtThreePlayerTables(t *testing.T) {
+	rules, _ := game.GetRuleSetPreset(game.ThreePlayerRuleSetPreset)
+	gm := game.NewBeloteGameWithDealer(rules, game.NewSeededDealerFactory(3))
+	gm.Start()
+
+	heuristic := NewHeuristicPlayer()
+	for {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if gm.GetHand().GetState() == game.HandInProgress && player == game.Player3 {
+			break
+		}
+		action, err := heuristic.ChooseAction(gm.GetPlayerView(player))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gm.PlayAction(player, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bot := NewSeededISMCTSPlayer(SearchBudget{Iterations: 1}, 1)
+	root := &searchNode{}
+	hand := gm.GetHand().Clone()
+	bot.iterate(root, hand)
+
+	child := root.children[0]
+	if child.player != game.Player3 {
+		t.Fatalf("expected player 3 to play first, got %d", child.player)
+	}
+	if expected := teamShare(hand, game.Team3); child.reward != expected {
+		t.Errorf("expected player 3 to be rewarded with its own share %v, got %v", expected, child.reward)
+	}
+}
+
+func TestISMCTSBotIsDeterministicWithSeed(t *testing.T) {
+	gm := game.NewBeloteGameWithDealer(game.DefaultRuleSet(), game.NewSeededDealerFactory(2))
+	gm.Start()
+
+	heuristic := NewHeuristicPlayer()
+	for gm.GetHand().GetState() != game.HandInProgress || len(gm.GetHand().LegalCards(gm.GetHand().StartingPlayer)) < 2 {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		action, err := heuristic.ChooseAction(gm.GetPlayerView(player))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gm.PlayAction(player, action); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	view := gm.GetPlayerView(gm.GetHand().StartingPlayer)
+	first, err := NewSeededISMCTSPlayer(SearchBudget{Iterations: 200}, 7).ChooseAction(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := NewSeededISMCTSPlayer(SearchBudget{Iterations: 200}, 7).ChooseAction(view)
+	if first.Card != second.Card {
+		t.Errorf("expected the same card from the same seed, got %v and %v", first.Card, second.Card)
+	}
+}
+
+func TestCardInferenceRespectsRevealedVoids(t *testing.T) {
+	trick := game.NewTrick(game.Player1, game.TrumpContract(game.Spades), game.FourPlayerTable)
+	trick.Cards[game.Player1] = game.Card{Suit: game.Hearts, Rank: game.Seven}
+	trick.Cards[game.Player2] = game.Card{Suit: game.Clubs, Rank: game.Eight}
+	trick.Cards[game.Player3] = game.Card{Suit: game.Hearts, Rank: game.Ace}
+	trick.Cards[game.Player4] = game.Card{Suit: game.Spades, Rank: game.Seven}
+
+	view := game.PlayerView{
+		Player: game.Player1,
+		State:  game.HandInProgress,
+		Cards: []game.Card{
+			{Suit: game.Hearts, Rank: game.Eight},
+			{Suit: game.Hearts, Rank: game.Nine},
+			{Suit: game.Diamonds, Rank: game.Seven},
+			{Suit: game.Diamonds, Rank: game.Eight},
+			{Suit: game.Clubs, Rank: game.Seven},
+			{Suit: game.Clubs, Rank: game.Nine},
+			{Suit: game.Clubs, Rank: game.Ten},
+		},
+		TableTrumpCard:  game.Card{Suit: game.Spades, Rank: game.Jack},
+		Trump:           game.TrumpContract(game.Spades),
+		Taker:           game.Player3,
+		CurrentTrick:    game.NewTrick(game.Player4, game.TrumpContract(game.Spades), game.FourPlayerTable),
+		PreviousTrick:   trick,
+		CompletedTricks: []*game.Trick{trick},
+	}
+
+	inference := newCardInference(view)
+	r := rand.New(rand.NewPCG(1, 1))
+	for range 100 {
+		hands := inference.deal(r)
+
+		seen := map[game.Card]bool{}
+		for player, cards := range hands {
+			if len(cards) != game.NUM_CARDS_PER_PLAYER-1 {
+				t.Fatalf("expected player %d to get %d cards, got %d", player, game.NUM_CARDS_PER_PLAYER-1, len(cards))
+			}
+			for _, card := range cards {
+				if seen[card] || !newCardMemory(view).outstanding(card) {
+					t.Fatalf("dealt %v, which is not an unseen card", card)
+				}
+				seen[card] = true
+			}
+		}
+
+		for _, card := range hands[game.Player2] {
+			if card.Suit == game.Hearts || card.Suit == game.Spades {
+				t.Fatalf("expected Player2 to be void in Hearts and Spades, got %v", card)
+			}
+		}
+		if !hasCard(hands[game.Player3], view.TableTrumpCard) {
+			t.Fatalf("expected the taker to hold the table trump card")
+		}
+	}
+}
+
+func TestCardInferenceRecordsUndertrumping(t *testing.T) {
+	inference := cardInference{forbidden: map[game.PlayerId]game.CardSet{}}
+
+	partial := game.NewTrick(game.Player1, game.TrumpContract(game.Spades), game.FourPlayerTable)
+	partial.Cards[game.Player1] = game.Card{Suit: game.Hearts, Rank: game.Seven}
+	partial.Cards[game.Player2] = game.Card{Suit: game.Spades, Rank: game.Ace}
+	inference.observeFollow(partial, game.Player3, game.Card{Suit: game.Spades, Rank: game.Eight})
+
+	for _, rank := range []game.Rank{game.Nine, game.Jack} {
+		if !inference.forbidden[game.Player3].Contains(game.Card{Suit: game.Spades, Rank: rank}) {
+			t.Errorf("expected Player3 not to hold the %s of trumps", rank)
+		}
+	}
+	if inference.forbidden[game.Player3].Contains(game.Card{Suit: game.Spades, Rank: game.King}) {
+		t.Errorf("expected Player3 to possibly hold a lower trump")
+	}
+	if !inference.forbidden[game.Player3].Contains(game.Card{Suit: game.Hearts, Rank: game.King}) {
+		t.Errorf("expected Player3 to be void in the lead suit")
+	}
+}
+
+func TestCardInferenceDealsRevealedDeclarations(t *testing.T) {
+	revealed := game.NewCardSet(
+		game.Card{Suit: game.Diamonds, Rank: game.Queen},
+		game.Card{Suit: game.Diamonds, Rank: game.King},
+		game.Card{Suit: game.Diamonds, Rank: game.Ace},
+	)
+	view := game.PlayerView{
+		Player: game.Player1,
+		State:  game.HandInProgress,
+		Cards: []game.Card{
+			{Suit: game.Hearts, Rank: game.Seven},
+			{Suit: game.Hearts, Rank: game.Eight},
+			{Suit: game.Hearts, Rank: game.Nine},
+			{Suit: game.Clubs, Rank: game.Seven},
+			{Suit: game.Clubs, Rank: game.Eight},
+			{Suit: game.Clubs, Rank: game.Nine},
+			{Suit: game.Spades, Rank: game.Seven},
+			{Suit: game.Spades, Rank: game.Eight},
+		},
+		Trump:         game.TrumpContract(game.Spades),
+		Taker:         game.Player1,
+		CurrentTrick:  game.NewTrick(game.Player1, game.TrumpContract(game.Spades), game.FourPlayerTable),
+		RevealedCards: map[game.PlayerId]game.CardSet{game.Player2: revealed},
+	}
+
+	inference := newCardInference(view)
+	r := rand.New(rand.NewPCG(1, 1))
+	for range 20 {
+		hands := inference.deal(r)
+		for _, card := range revealed.Cards() {
+			if !hasCard(hands[game.Player2], card) {
+				t.Fatalf("expected Player2 to hold the revealed %v", card)
+			}
+		}
+	}
+}
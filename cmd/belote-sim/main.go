@@ -0,0 +1,142 @@
+// Command belote-sim plays complete games between bots, without a server,
+// and reports how the games went. It is meant for tuning house rules and bot
+// strength:
+//
+//	belote-sim -games 1000 -team1 ismcts -team2 heuristic -rules strict -format csv
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+func main() {
+	var (
+		games   = flag.Int("games", 100, "number of games to play")
+		seed    = flag.Uint64("seed", 0, "seed of the first game, game i uses seed+i; 0 picks a random seed")
+		team1   = flag.String("team1", "heuristic", "strategy of team 1: "+strategyNames())
+		team2   = flag.String("team2", "heuristic", "strategy of team 2: "+strategyNames())
+		rules   = flag.String("rules", game.StandardRuleSetPreset, "rule set preset")
+		format  = flag.String("format", "json", "output format: json or csv")
+		workers = flag.Int("parallel", runtime.GOMAXPROCS(0), "number of games played at once")
+	)
+	flag.Parse()
+
+	ruleSet, ok := game.GetRuleSetPreset(*rules)
+	if !ok {
+		log.Fatalf("unknown rule set preset %q", *rules)
+	}
+	if !ruleSet.Table().HasPartnerships() {
+		log.Fatalf("rule set preset %q is not played by two teams", *rules)
+	}
+	for _, strategy := range []string{*team1, *team2} {
+		if _, ok := strategies[strategy]; !ok {
+			log.Fatalf("unknown strategy %q, expected one of %s", strategy, strategyNames())
+		}
+	}
+	if *seed == 0 {
+		*seed = rand.Uint64()
+	}
+
+	cfg := config{
+		Games:      *games,
+		Seed:       *seed,
+		Rules:      ruleSet,
+		Strategies: map[game.TeamId]string{game.Team1: *team1, game.Team2: *team2},
+		Workers:    *workers,
+	}
+	results, err := simulate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report := newReport(cfg, results)
+	switch *format {
+	case "json":
+		err = writeJSON(os.Stdout, report)
+	case "csv":
+		err = writeCSV(os.Stdout, report)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func strategyNames() string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+func writeJSON(w io.Writer, report Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// writeCSV writes the report as a header and a single row, so that the output
+// of several runs can be appended to one file.
+func writeCSV(w io.Writer, report Report) error {
+	header := []string{"games", "seed", "draws", "hands", "averageHandsPerGame", "firstRoundRate"}
+	row := []string{
+		strconv.Itoa(report.Games),
+		strconv.FormatUint(report.Seed, 10),
+		strconv.Itoa(report.Draws),
+		strconv.Itoa(report.Hands),
+		formatFloat(report.AverageHandsPerGame),
+		formatFloat(report.FirstRoundRate),
+	}
+
+	for _, team := range report.Teams {
+		prefix := fmt.Sprintf("team%d", team.Team)
+		for _, column := range []struct {
+			name  string
+			value string
+		}{
+			{"Strategy", team.Strategy},
+			{"Wins", strconv.Itoa(team.Wins)},
+			{"WinRate", formatFloat(team.WinRate)},
+			{"AverageHandScore", formatFloat(team.AverageHandScore)},
+			{"Taken", strconv.Itoa(team.Taken)},
+			{"TakenRate", formatFloat(team.TakenRate)},
+			{"Failed", strconv.Itoa(team.Failed)},
+			{"FailedRate", formatFloat(team.FailedRate)},
+		} {
+			header = append(header, prefix+column.name)
+			row = append(row, column.value)
+		}
+	}
+
+	for _, name := range sortedDeclarationNames(report) {
+		decl := report.Declarations[name]
+		header = append(header, name+"Count", name+"PerHand")
+		row = append(row, strconv.Itoa(decl.Count), formatFloat(decl.PerHand))
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll([][]string{header, row}); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/los-dogos-studio/gurian-belote/bot"
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+const maxMovesPerGame = 100000
+
+// strategies builds a fresh bot for a seat. Bots are not safe for concurrent
+// use, so every game gets its own.
+var strategies = map[string]func(seed uint64) bot.Player{
+	"heuristic": func(uint64) bot.Player {
+		return bot.NewHeuristicPlayer()
+	},
+	"ismcts": func(seed uint64) bot.Player {
+		return bot.NewSeededISMCTSPlayer(bot.NormalSearchBudget, seed)
+	},
+	"ismcts-hard": func(seed uint64) bot.Player {
+		return bot.NewSeededISMCTSPlayer(bot.HardSearchBudget, seed)
+	},
+}
+
+var declarationNames = map[game.PreHandDeclarationType]string{
+	game.Tierce:     "tierce",
+	game.Quarte:     "quarte",
+	game.Quinte:     "quinte",
+	game.Carre:      "carre",
+	game.NinesCarre: "ninesCarre",
+	game.JacksCarre: "jacksCarre",
+}
+
+const beloteName = "belote"
+
+// config describes a simulation run.
+type config struct {
+	Games      int
+	Seed       uint64
+	Rules      game.RuleSet
+	Strategies map[game.TeamId]string
+	Workers    int
+}
+
+// gameResult is what a single simulated game leaves behind.
+type gameResult struct {
+	Winner game.TeamId
+	Hands  []handResult
+}
+
+type handResult struct {
+	game.HandResult
+	FirstRound   bool
+	Declarations []string
+}
+
+// Report aggregates the results of every game of a run.
+type Report struct {
+	Games int    `json:"games"`
+	Seed  uint64 `json:"seed"`
+	Draws int    `json:"draws"`
+	Hands int    `json:"hands"`
+
+	AverageHandsPerGame float64 `json:"averageHandsPerGame"`
+
+	// FirstRoundRate is the share of hands whose trump was taken on the table
+	// trump card rather than in the free selection.
+	FirstRoundRate float64 `json:"firstRoundRate"`
+
+	Teams []TeamReport `json:"teams"`
+
+	// Declarations counts the declarations made, by kind, with the average
+	// number made per hand.
+	Declarations map[string]DeclarationReport `json:"declarations"`
+}
+
+type TeamReport struct {
+	Team     game.TeamId `json:"team"`
+	Strategy string      `json:"strategy"`
+	Wins     int         `json:"wins"`
+	WinRate  float64     `json:"winRate"`
+
+	// AverageHandScore is the average of the points credited to the team per hand.
+	AverageHandScore float64 `json:"averageHandScore"`
+
+	// TakenRate is the share of hands the team took the trump in, FailedRate
+	// the share of those in which the team went inside.
+	Taken      int     `json:"taken"`
+	TakenRate  float64 `json:"takenRate"`
+	Failed     int     `json:"failed"`
+	FailedRate float64 `json:"failedRate"`
+}
+
+type DeclarationReport struct {
+	Count   int     `json:"count"`
+	PerHand float64 `json:"perHand"`
+}
+
+// simulate plays cfg.Games games across cfg.Workers goroutines. Game i is
+// dealt and played with seeds derived from cfg.Seed+i, so a run is
+// reproducible whatever the number of workers.
+func simulate(cfg config) ([]gameResult, error) {
+	results := make([]gameResult, cfg.Games)
+	errs := make([]error, cfg.Games)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(cfg.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = playGame(cfg, cfg.Seed+uint64(i))
+			}
+		}()
+	}
+	for i := range cfg.Games {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %w", i, err)
+		}
+	}
+	return results, nil
+}
+
+func playGame(cfg config, seed uint64) (gameResult, error) {
+	players := map[game.PlayerId]bot.Player{}
+	for player := game.Player1; player <= game.Player4; player++ {
+		newPlayer, ok := strategies[cfg.Strategies[player.GetTeam()]]
+		if !ok {
+			return gameResult{}, fmt.Errorf("unknown strategy %q", cfg.Strategies[player.GetTeam()])
+		}
+		players[player] = newPlayer(seed*game.NUM_PLAYERS + uint64(player))
+	}
+
+	gm := game.NewBeloteGameWithDealer(cfg.Rules, game.NewSeededDealerFactory(seed))
+	gm.Start()
+
+	var result gameResult
+	var recorded *game.Hand
+	for moves := 0; gm.GetState() != game.GameFinished; moves++ {
+		if moves > maxMovesPerGame {
+			return gameResult{}, fmt.Errorf("game did not finish")
+		}
+		if err := playNextAction(&gm, players); err != nil {
+			return gameResult{}, err
+		}
+
+		if hand := gm.GetHand(); hand != nil && hand.GetState() == game.HandFinished && hand != recorded {
+			recorded = hand
+			sheet := gm.GetScoreSheet()
+			result.Hands = append(result.Hands, newHandResult(sheet[len(sheet)-1], hand))
+		}
+	}
+
+	scores := gm.GetScores()
+	switch {
+	case scores[game.Team1] > scores[game.Team2]:
+		result.Winner = game.Team1
+	case scores[game.Team2] > scores[game.Team1]:
+		result.Winner = game.Team2
+	}
+	return result, nil
+}
+
+// playNextAction lets the first player with a legal action act.
+func playNextAction(gm *game.BeloteGame, players map[game.PlayerId]bot.Player) error {
+	for player := game.Player1; player <= game.Player4; player++ {
+		view := gm.GetPlayerView(player)
+		if len(view.LegalActions) == 0 {
+			continue
+		}
+
+		action, err := players[player].ChooseAction(view)
+		if err != nil {
+			return fmt.Errorf("player %d: %w", player, err)
+		}
+		if err := gm.PlayAction(player, action); err != nil {
+			return fmt.Errorf("player %d chose %+v: %w", player, action, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no player can act in state %s", gm.GetState())
+}
+
+func newHandResult(result game.HandResult, hand *game.Hand) handResult {
+	tableTrump := hand.GetTableTrump()
+	r := handResult{
+		HandResult: result,
+		// A Jack turned up goes to the last player without a table trump card.
+		// Coinche hands are bid for instead.
+		FirstRound: len(hand.Bids) == 0 &&
+			(tableTrump == (game.Card{}) || hand.GetTrump() == game.TrumpContract(tableTrump.Suit)),
+	}
+
+	for player := game.Player1; player <= game.Player4; player++ {
+		for _, decl := range hand.PlayerDeclarations[player] {
+			switch d := decl.(type) {
+			case game.PreHandDeclaration:
+				r.Declarations = append(r.Declarations, declarationNames[d.Type])
+			case game.Belote:
+				r.Declarations = append(r.Declarations, beloteName)
+			}
+		}
+	}
+	return r
+}
+
+func newReport(cfg config, results []gameResult) Report {
+	report := Report{
+		Games:        len(results),
+		Seed:         cfg.Seed,
+		Declarations: map[string]DeclarationReport{},
+	}
+
+	teams := map[game.TeamId]*TeamReport{}
+	credited := map[game.TeamId]int{}
+	for _, team := range []game.TeamId{game.Team1, game.Team2} {
+		teams[team] = &TeamReport{Team: team, Strategy: cfg.Strategies[team]}
+	}
+	for _, name := range declarationNames {
+		report.Declarations[name] = DeclarationReport{}
+	}
+	report.Declarations[beloteName] = DeclarationReport{}
+
+	firstRound := 0
+	for _, result := range results {
+		if result.Winner == game.NoTeamId {
+			report.Draws++
+		} else {
+			teams[result.Winner].Wins++
+		}
+
+		for _, hand := range result.Hands {
+			report.Hands++
+			if hand.FirstRound {
+				firstRound++
+			}
+			for team, points := range hand.Credited {
+				credited[team] += points
+			}
+
+			// Redealt hands have no takers, and hung hands are not lost
+			// yet.
+			if taker, ok := teams[hand.TakerTeam]; ok {
+				taker.Taken++
+				if hand.Outcome == game.ContractInside {
+					taker.Failed++
+				}
+			}
+
+			for _, name := range hand.Declarations {
+				decl := report.Declarations[name]
+				decl.Count++
+				report.Declarations[name] = decl
+			}
+		}
+	}
+
+	report.AverageHandsPerGame = ratio(report.Hands, report.Games)
+	report.FirstRoundRate = ratio(firstRound, report.Hands)
+	for name, decl := range report.Declarations {
+		decl.PerHand = ratio(decl.Count, report.Hands)
+		report.Declarations[name] = decl
+	}
+	for _, team := range []game.TeamId{game.Team1, game.Team2} {
+		t := teams[team]
+		t.WinRate = ratio(t.Wins, report.Games)
+		t.AverageHandScore = ratio(credited[team], report.Hands)
+		t.TakenRate = ratio(t.Taken, report.Hands)
+		t.FailedRate = ratio(t.Failed, t.Taken)
+		report.Teams = append(report.Teams, *t)
+	}
+	return report
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// sortedDeclarationNames lists the declaration kinds in a stable order.
+func sortedDeclarationNames(report Report) []string {
+	names := make([]string, 0, len(report.Declarations))
+	for name := range report.Declarations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+func newTestConfig(workers int) config {
+	rules, _ := game.GetRuleSetPreset(game.QuickRuleSetPreset)
+	return config{
+		Games:      6,
+		Seed:       42,
+		Rules:      rules,
+		Strategies: map[game.TeamId]string{game.Team1: "heuristic", game.Team2: "heuristic"},
+		Workers:    workers,
+	}
+}
+
+func TestSimulateIsReproducible(t *testing.T) {
+	sequential, err := simulate(newTestConfig(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := simulate(newTestConfig(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("expected the same results whatever the number of workers")
+	}
+}
+
+func TestReportAddsUp(t *testing.T) {
+	cfg := newTestConfig(2)
+	results, err := simulate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := newReport(cfg, results)
+
+	if report.Games != cfg.Games {
+		t.Errorf("expected %d games, got %d", cfg.Games, report.Games)
+	}
+	if wins := report.Teams[0].Wins + report.Teams[1].Wins + report.Draws; wins != report.Games {
+		t.Errorf("expected wins and draws to add up to %d games, got %d", report.Games, wins)
+	}
+	if taken := report.Teams[0].Taken + report.Teams[1].Taken; taken > report.Hands {
+		t.Errorf("expected at most one taker for each of the %d hands, got %d", report.Hands, taken)
+	}
+	for _, team := range report.Teams {
+		if team.Failed > team.Taken {
+			t.Errorf("expected team %d to fail at most the %d hands it took, got %d", team.Team, team.Taken, team.Failed)
+		}
+	}
+}
+
+func TestReportCountsOnlyInsideHandsAsFailed(t *testing.T) {
+	cfg := newTestConfig(1)
+	hands := []handResult{
+		{HandResult: game.HandResult{TakerTeam: game.Team1, Outcome: game.ContractMade}},
+		{HandResult: game.HandResult{TakerTeam: game.Team1, Outcome: game.ContractHung}},
+		{HandResult: game.HandResult{TakerTeam: game.Team2, Outcome: game.ContractInside}},
+		{HandResult: game.HandResult{TakerTeam: game.NoTeamId}},
+	}
+	report := newReport(cfg, []gameResult{{Winner: game.Team1, Hands: hands}})
+
+	if report.Hands != len(hands) {
+		t.Errorf("expected %d hands, got %d", len(hands), report.Hands)
+	}
+	if team := report.Teams[0]; team.Taken != 2 || team.Failed != 0 {
+		t.Errorf("expected team 1 to take 2 hands and fail none, got %d and %d", team.Taken, team.Failed)
+	}
+	if team := report.Teams[1]; team.Taken != 1 || team.Failed != 1 {
+		t.Errorf("expected team 2 to take 1 hand and fail it, got %d and %d", team.Taken, team.Failed)
+	}
+}
+
+func TestSimulateRejectsUnknownStrategy(t *testing.T) {
+	cfg := newTestConfig(1)
+	cfg.Strategies[game.Team2] = "oracle"
+	if _, err := simulate(cfg); err == nil {
+		t.Errorf("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	cfg := newTestConfig(1)
+	results, err := simulate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, newReport(cfg, results)); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || len(records[0]) != len(records[1]) {
+		t.Errorf("expected a header and a row of the same width, got %v", records)
+	}
+}
@@ -0,0 +1,32 @@
+package game
+
+type ActionType string
+
+const (
+	AcceptTableTrumpAction ActionType = "AcceptTableTrump"
+	SelectTrumpAction      ActionType = "SelectTrump"
+	SelectContractAction   ActionType = "SelectContract"
+	ContraAction           ActionType = "Contra"
+	RecontraAction         ActionType = "Recontra"
+	BidAction              ActionType = "Bid"
+	PlayCardAction         ActionType = "PlayCard"
+	ContinueAction         ActionType = "Continue"
+	CutAction              ActionType = "Cut"
+)
+
+// Action is a move a player can make. Only the fields relevant to Type are set:
+// Accept for AcceptTableTrumpAction, ContraAction and RecontraAction (false is
+// a pass), Suit for SelectTrumpAction (nil is a pass), Contract for
+// SelectContractAction, Bid for BidAction (nil is a pass), Card for
+// PlayCardAction and Cut, the number of cards lifted, for CutAction. During
+// an auction, the coinche or double is a ContraAction and the redouble a
+// RecontraAction.
+type Action struct {
+	Type     ActionType `json:"type"`
+	Accept   bool       `json:"accept,omitempty"`
+	Suit     *Suit      `json:"suit,omitempty"`
+	Contract *Contract  `json:"contract,omitempty"`
+	Bid      *Bid       `json:"bid,omitempty"`
+	Card     Card       `json:"card"`
+	Cut      int        `json:"cut,omitempty"`
+}
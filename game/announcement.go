@@ -0,0 +1,157 @@
+package game
+
+import "fmt"
+
+// Announcement is what a player announces along with a card: pre-hand
+// declarations with their first card, Belote with the first of the King and
+// Queen of trumps. Announcing is optional, so players may keep any of them
+// to themselves.
+type Announcement struct {
+	Declarations []PreHandDeclaration `json:"declarations,omitempty"`
+	Belote       bool                 `json:"belote,omitempty"`
+}
+
+var (
+	ErrDeclarationNotHeld    = fmt.Errorf("player does not hold this declaration")
+	ErrDeclarationTooLate    = fmt.Errorf("declarations can only be announced with the first card")
+	ErrDeclarationsOverlap   = fmt.Errorf("a card cannot be part of two declarations")
+	ErrBeloteNotAnnounceable = fmt.Errorf("belote can only be announced with the first of the King and Queen of trumps")
+)
+
+// PossibleDeclarations returns the pre-hand declarations player may announce
+// with their next card. Declarations sharing a card cannot be announced
+// together.
+func (h *Hand) PossibleDeclarations(player PlayerId) []PreHandDeclaration {
+	if h.State != HandInProgress || h.PreviousTrick != nil {
+		return nil
+	}
+	if _, played := h.CurrentTrick.Cards[player]; played {
+		return nil
+	}
+	return h.findPreHandDeclarations(h.PlayerCards[player])
+}
+
+// BestAnnouncement returns the most valuable announcement player can make
+// with card: the declarations worth the most points together, and Belote if
+// it can be announced.
+func (h *Hand) BestAnnouncement(player PlayerId, card Card) Announcement {
+	playerCards := h.PlayerCards[player]
+	possible := h.PossibleDeclarations(player)
+
+	var best Announcement
+	bestPoints := 0
+	for subset := 1; subset < 1<<len(possible); subset++ {
+		var declarations []PreHandDeclaration
+		points := 0
+		for i, d := range possible {
+			if subset&(1<<i) != 0 {
+				declarations = append(declarations, d)
+				points += h.rules.DeclarationValue(d)
+			}
+		}
+		if points > bestPoints && !declarationsOverlap(declarations, playerCards) {
+			best.Declarations, bestPoints = declarations, points
+		}
+	}
+
+	best.Belote = h.canAnnounceBelote(card, playerCards)
+	return best
+}
+
+// checkAnnouncement validates announcement against the cards of player and
+// returns the declarations held matching the announced ones.
+func (h *Hand) checkAnnouncement(player PlayerId, card Card, announcement Announcement) ([]PreHandDeclaration, error) {
+	playerCards := h.PlayerCards[player]
+
+	if announcement.Belote && !h.canAnnounceBelote(card, playerCards) {
+		return nil, ErrBeloteNotAnnounceable
+	}
+	if len(announcement.Declarations) == 0 {
+		return nil, nil
+	}
+	if h.PreviousTrick != nil {
+		return nil, ErrDeclarationTooLate
+	}
+
+	possible := h.findPreHandDeclarations(playerCards)
+	declarations := make([]PreHandDeclaration, 0, len(announcement.Declarations))
+	for _, d := range announcement.Declarations {
+		held, ok := findDeclaration(possible, d)
+		if !ok {
+			return nil, ErrDeclarationNotHeld
+		}
+		declarations = append(declarations, held)
+	}
+	if declarationsOverlap(declarations, playerCards) {
+		return nil, ErrDeclarationsOverlap
+	}
+	return declarations, nil
+}
+
+// findPreHandDeclarations returns the declarations in cards that the contract
+// of the hand lets be announced.
+func (h *Hand) findPreHandDeclarations(cards CardSet) []PreHandDeclaration {
+	if !h.Trump.AllowsDeclarations() {
+		return nil
+	}
+	return h.rules.FindPreHandDeclarations(cards.Cards())
+}
+
+// canAnnounceBelote tells whether card is the first of the King and Queen of
+// trumps to leave playerCards.
+func (h *Hand) canAnnounceBelote(card Card, playerCards CardSet) bool {
+	return h.Trump.AllowsBelote(card.Suit) && (card.Rank == King || card.Rank == Queen) &&
+		playerCards.Contains(Card{Suit: card.Suit, Rank: King}) &&
+		playerCards.Contains(Card{Suit: card.Suit, Rank: Queen})
+}
+
+// findDeclaration looks d up in declarations. Carres are told apart by their
+// rank only.
+func findDeclaration(declarations []PreHandDeclaration, d PreHandDeclaration) (PreHandDeclaration, bool) {
+	for _, other := range declarations {
+		if other.Type != d.Type {
+			continue
+		}
+		if other.HighestCard == d.HighestCard || (isCarre(d.Type) && other.HighestCard.Rank == d.HighestCard.Rank) {
+			return other, true
+		}
+	}
+	return PreHandDeclaration{}, false
+}
+
+func isCarre(t PreHandDeclarationType) bool {
+	return t == Carre || t == NinesCarre || t == JacksCarre
+}
+
+func declarationsOverlap(declarations []PreHandDeclaration, playerCards CardSet) bool {
+	var used CardSet
+	for _, d := range declarations {
+		cards := declarationCards(d, playerCards)
+		if !used.Intersection(cards).IsEmpty() {
+			return true
+		}
+		used = used.Union(cards)
+	}
+	return false
+}
+
+// declarationCards returns the cards of playerCards making up d. A sequence
+// runs down from its highest card for as long as the player holds the cards.
+func declarationCards(d PreHandDeclaration, playerCards CardSet) CardSet {
+	var cards CardSet
+	if isCarre(d.Type) {
+		for _, suit := range suits {
+			cards = cards.With(Card{Suit: suit, Rank: d.HighestCard.Rank})
+		}
+		return cards
+	}
+
+	for i := d.HighestCard.Rank.NaturalOrder(); i >= 0; i-- {
+		card := Card{Suit: d.HighestCard.Suit, Rank: ranks[i]}
+		if !playerCards.Contains(card) {
+			break
+		}
+		cards = cards.With(card)
+	}
+	return cards
+}
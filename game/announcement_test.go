@@ -0,0 +1,181 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newAnnouncementHand returns a hand in its first trick in which Player1
+// holds a tierce to the King of Spades, the four Jacks and Belote in Hearts.
+func newAnnouncementHand() *Hand {
+	return &Hand{
+		State:             HandInProgress,
+		CurrentTrick:      NewTrick(Player1, TrumpContract(Hearts), FourPlayerTable),
+		StartingPlayer:    Player1,
+		Totals:            map[TeamId]int{Team1: 0, Team2: 0},
+		TrickPoints:       map[TeamId]int{Team1: 0, Team2: 0},
+		BelotePoints:      map[TeamId]int{Team1: 0, Team2: 0},
+		DeclarationPoints: map[TeamId]int{Team1: 0, Team2: 0},
+		PlayerCards: map[PlayerId]CardSet{
+			Player1: NewCardSet(
+				Card{Suit: Spades, Rank: Jack},
+				Card{Suit: Spades, Rank: Queen},
+				Card{Suit: Spades, Rank: King},
+				Card{Suit: Hearts, Rank: Jack},
+				Card{Suit: Diamonds, Rank: Jack},
+				Card{Suit: Clubs, Rank: Jack},
+				Card{Suit: Hearts, Rank: Queen},
+				Card{Suit: Hearts, Rank: King},
+			),
+			Player2: NewCardSet(Card{Suit: Hearts, Rank: Seven}),
+			Player3: NewCardSet(Card{Suit: Hearts, Rank: Eight}),
+			Player4: NewCardSet(Card{Suit: Hearts, Rank: Nine}),
+		},
+		PlayerDeclarations: map[PlayerId][]Declaration{},
+		Trump:              TrumpContract(Hearts),
+		rules:              DefaultRuleSet(),
+	}
+}
+
+var (
+	announcedTierce = PreHandDeclaration{Type: Tierce, HighestCard: Card{Suit: Spades, Rank: King}}
+	announcedCarre  = PreHandDeclaration{Type: JacksCarre, HighestCard: Card{Suit: Spades, Rank: Jack}}
+)
+
+func TestBestAnnouncement(t *testing.T) {
+	hand := newAnnouncementHand()
+
+	got := hand.BestAnnouncement(Player1, Card{Suit: Hearts, Rank: Queen})
+	expected := Announcement{Declarations: []PreHandDeclaration{announcedCarre}, Belote: true}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	got = hand.BestAnnouncement(Player1, Card{Suit: Spades, Rank: Jack})
+	if got.Belote {
+		t.Errorf("expected no Belote with the Jack of Spades")
+	}
+
+	if got := hand.BestAnnouncement(Player2, Card{Suit: Hearts, Rank: Seven}); len(got.Declarations) != 0 || got.Belote {
+		t.Errorf("expected nothing to announce for Player2, got %+v", got)
+	}
+}
+
+func TestPlayCardAnnouncesChosenDeclarations(t *testing.T) {
+	hand := newAnnouncementHand()
+
+	announcement := Announcement{Declarations: []PreHandDeclaration{announcedTierce}}
+	if err := hand.PlayCard(Player1, Card{Suit: Clubs, Rank: Jack}, announcement); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Declaration{announcedTierce}
+	if !reflect.DeepEqual(hand.PlayerDeclarations[Player1], expected) {
+		t.Errorf("expected only the tierce to be announced, got %+v", hand.PlayerDeclarations[Player1])
+	}
+}
+
+func TestPlayCardAnnouncesBelote(t *testing.T) {
+	hand := newAnnouncementHand()
+
+	if err := hand.PlayCard(Player1, Card{Suit: Hearts, Rank: King}, Announcement{Belote: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hand.PlayerDeclarations[Player1], []Declaration{Belote{}}) {
+		t.Errorf("expected Belote to be announced, got %+v", hand.PlayerDeclarations[Player1])
+	}
+}
+
+func TestPlayCardRejectsInvalidAnnouncements(t *testing.T) {
+	quarte := PreHandDeclaration{Type: Quarte, HighestCard: Card{Suit: Spades, Rank: King}}
+
+	tests := []struct {
+		name         string
+		card         Card
+		announcement Announcement
+		setup        func(*Hand)
+		err          error
+	}{
+		{
+			name:         "declaration not held",
+			card:         Card{Suit: Clubs, Rank: Jack},
+			announcement: Announcement{Declarations: []PreHandDeclaration{quarte}},
+			err:          ErrDeclarationNotHeld,
+		},
+		{
+			name:         "overlapping declarations",
+			card:         Card{Suit: Clubs, Rank: Jack},
+			announcement: Announcement{Declarations: []PreHandDeclaration{announcedTierce, announcedCarre}},
+			err:          ErrDeclarationsOverlap,
+		},
+		{
+			name:         "after the first trick",
+			card:         Card{Suit: Clubs, Rank: Jack},
+			announcement: Announcement{Declarations: []PreHandDeclaration{announcedTierce}},
+			setup:        func(h *Hand) { h.PreviousTrick = NewTrick(Player1, TrumpContract(Hearts), FourPlayerTable) },
+			err:          ErrDeclarationTooLate,
+		},
+		{
+			name:         "belote with another card",
+			card:         Card{Suit: Clubs, Rank: Jack},
+			announcement: Announcement{Belote: true},
+			err:          ErrBeloteNotAnnounceable,
+		},
+		{
+			name:         "belote with the second of King and Queen",
+			card:         Card{Suit: Hearts, Rank: King},
+			announcement: Announcement{Belote: true},
+			setup: func(h *Hand) {
+				h.PlayerCards[Player1] = h.PlayerCards[Player1].Without(Card{Suit: Hearts, Rank: Queen})
+			},
+			err: ErrBeloteNotAnnounceable,
+		},
+	}
+
+	for _, test := range tests {
+		hand := newAnnouncementHand()
+		if test.setup != nil {
+			test.setup(hand)
+		}
+		cards := hand.PlayerCards[Player1]
+
+		err := hand.PlayCard(Player1, test.card, test.announcement)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+		if hand.PlayerCards[Player1] != cards || len(hand.PlayerDeclarations[Player1]) != 0 {
+			t.Errorf("%s: expected the hand to be left unchanged", test.name)
+		}
+	}
+}
+
+func TestWinningDeclarationsAreRevealedAfterFirstTrick(t *testing.T) {
+	hand := newAnnouncementHand()
+	hand.PlayerCards[Player2] = hand.PlayerCards[Player2].With(Card{Suit: Diamonds, Rank: Seven})
+	hand.PlayerCards[Player3] = hand.PlayerCards[Player3].With(Card{Suit: Diamonds, Rank: Eight})
+	hand.PlayerCards[Player4] = hand.PlayerCards[Player4].With(Card{Suit: Diamonds, Rank: Nine})
+
+	announcement := Announcement{Declarations: []PreHandDeclaration{announcedCarre}}
+	if err := hand.PlayCard(Player1, Card{Suit: Hearts, Rank: Jack}, announcement); err != nil {
+		t.Fatal(err)
+	}
+	if len(hand.RevealedCards) != 0 {
+		t.Fatalf("expected nothing to be revealed during the first trick, got %v", hand.RevealedCards)
+	}
+	for player := Player2; player <= Player4; player++ {
+		if err := hand.PlayCard(player, hand.LegalCards(player)[0], Announcement{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[PlayerId]CardSet{Player1: NewCardSet(
+		Card{Suit: Spades, Rank: Jack},
+		Card{Suit: Hearts, Rank: Jack},
+		Card{Suit: Diamonds, Rank: Jack},
+		Card{Suit: Clubs, Rank: Jack},
+	)}
+	if !reflect.DeepEqual(hand.RevealedCards, expected) {
+		t.Errorf("expected the four Jacks to be revealed, got %v", hand.RevealedCards)
+	}
+}
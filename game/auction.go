@@ -0,0 +1,278 @@
+package game
+
+import "fmt"
+
+// Coinche and Bulgarian hands are bid for in an auction going round the table
+// from the starting player. Each player bids higher than the last bid or
+// passes, until every other player passed after a bid.
+//
+// Coinche bids are points in a contract. A defender of the last bid may
+// coinche it in their turn instead, which ends the auction and lets the
+// takers surcoinche.
+//
+// Bulgarian bids are contracts alone, ranked Clubs, Diamonds, Hearts, Spades,
+// no trumps and all trumps. The defenders of the last bid may double it and
+// the takers redouble it in their turn, and a higher bid cancels both.
+const (
+	MIN_BID  = 80
+	MAX_BID  = 160
+	BID_STEP = 10
+	// CAPOT_BID undertakes to take every trick and beats every other bid.
+	CAPOT_BID = 250
+)
+
+var (
+	ErrInvalidBid = fmt.Errorf("a bid is %d to %d points in steps of %d, or capot", MIN_BID, MAX_BID, BID_STEP)
+	ErrBidTooLow  = fmt.Errorf("a bid must be higher than the last one")
+)
+
+// bulgarianContractOrder ranks the contracts of a Bulgarian auction.
+var bulgarianContractOrder = map[Contract]int{
+	TrumpContract(Clubs):    0,
+	TrumpContract(Diamonds): 1,
+	TrumpContract(Hearts):   2,
+	TrumpContract(Spades):   3,
+	NoTrumpsContract:        4,
+	AllTrumpsContract:       5,
+}
+
+// Bid is a call of an auction: Player's team undertakes to score at least
+// Points in Contract, or to take every trick on a capot bid. Bulgarian bids
+// have no points.
+type Bid struct {
+	Player   PlayerId `json:"player"`
+	Points   int      `json:"points"`
+	Contract Contract `json:"contract"`
+}
+
+func (b Bid) IsCapot() bool {
+	return b.Points == CAPOT_BID
+}
+
+// bidLevels returns the points that can be bid, in increasing order.
+func bidLevels(mode GameMode) []int {
+	if mode == BulgarianMode {
+		return []int{0}
+	}
+
+	var levels []int
+	for points := MIN_BID; points <= MAX_BID; points += BID_STEP {
+		levels = append(levels, points)
+	}
+	return append(levels, CAPOT_BID)
+}
+
+// Bid places bid for player in the auction, or passes when bid is nil. The
+// bidder is always player, whatever bid names.
+func (h *Hand) Bid(player PlayerId, bid *Bid) error {
+	if err := h.checkIsBiddingTurnFor(player); err != nil {
+		return err
+	}
+
+	if bid == nil {
+		h.Calls++
+		h.Passes++
+		h.checkAuctionEnd()
+		return nil
+	}
+
+	placed := *bid
+	placed.Player = player
+	if err := h.checkBid(placed); err != nil {
+		return err
+	}
+	h.Bids = append(h.Bids, placed)
+	h.Calls++
+	h.Passes = 0
+	h.Multiplier = 1
+	return nil
+}
+
+func (h *Hand) checkIsBiddingTurnFor(player PlayerId) error {
+	if h.State != Bidding {
+		return fmt.Errorf("bidding is not in progress, current state: %s", h.State)
+	}
+	if player != h.getCurrentBiddingTurn() {
+		return fmt.Errorf("not player's turn")
+	}
+	return nil
+}
+
+func (h *Hand) checkBid(bid Bid) error {
+	if !h.rules.allowsContract(bid.Contract) {
+		return fmt.Errorf("contract %s is not allowed", bid.Contract)
+	}
+
+	last := h.lastBid()
+	if h.rules.Mode == BulgarianMode {
+		if bid.Points != 0 {
+			return fmt.Errorf("a Bulgarian bid names a contract without points")
+		}
+		if last != nil && bulgarianContractOrder[bid.Contract] <= bulgarianContractOrder[last.Contract] {
+			return ErrBidTooLow
+		}
+		return nil
+	}
+
+	if (bid.Points < MIN_BID || bid.Points > MAX_BID || bid.Points%BID_STEP != 0) && !bid.IsCapot() {
+		return ErrInvalidBid
+	}
+	if last != nil && bid.Points <= last.Points {
+		return ErrBidTooLow
+	}
+	return nil
+}
+
+// double answers the last bid with a contra: the Coinche coinche, which
+// closes the auction, or the Bulgarian double, which does not.
+func (h *Hand) double(player PlayerId, call bool) error {
+	if !call {
+		return fmt.Errorf("pass with a bid during the auction")
+	}
+	if err := h.checkIsBiddingTurnFor(player); err != nil {
+		return err
+	}
+	if !h.canDouble(player) {
+		return fmt.Errorf("player cannot double")
+	}
+
+	h.Multiplier = 2
+	if h.rules.Mode == CoincheMode {
+		h.takeBid()
+		h.State = RecontraSelection
+		return nil
+	}
+	h.Calls++
+	h.Passes = 0
+	return nil
+}
+
+// redouble answers a Bulgarian double with a recontra.
+func (h *Hand) redouble(player PlayerId, call bool) error {
+	if !call {
+		return fmt.Errorf("pass with a bid during the auction")
+	}
+	if err := h.checkIsBiddingTurnFor(player); err != nil {
+		return err
+	}
+	if !h.canRedouble(player) {
+		return fmt.Errorf("player cannot redouble")
+	}
+
+	h.Multiplier = 4
+	h.Calls++
+	h.Passes = 0
+	return nil
+}
+
+func (h *Hand) canDouble(player PlayerId) bool {
+	last := h.lastBid()
+	return h.rules.ContraAllowed && last != nil && h.Multiplier == 1 &&
+		h.table().Team(last.Player) != h.table().Team(player)
+}
+
+func (h *Hand) canRedouble(player PlayerId) bool {
+	last := h.lastBid()
+	return h.rules.Mode == BulgarianMode && last != nil && h.Multiplier == 2 &&
+		h.table().Team(last.Player) == h.table().Team(player)
+}
+
+// checkAuctionEnd gives the hand to the last bid once every other player
+// passed after it, and redeals it when every player passed without a bid.
+func (h *Hand) checkAuctionEnd() {
+	switch {
+	case len(h.Bids) == 0 && h.Passes == h.table().Players():
+		h.State = HandRedeal
+	case len(h.Bids) > 0 && h.Passes == h.table().Players()-1:
+		h.takeBid()
+	}
+}
+
+// takeBid makes the last bid the contract of the hand and deals the cards
+// still to be dealt.
+func (h *Hand) takeBid() {
+	bid := h.lastBid()
+	h.Taker = bid.Player
+	h.TakerTeam = h.table().Team(bid.Player)
+	h.Trump = bid.Contract
+	h.State = HandInProgress
+	h.CurrentTrick = NewTrick(h.StartingPlayer, h.Trump, h.table())
+	h.dealCards()
+}
+
+func (h *Hand) lastBid() *Bid {
+	if len(h.Bids) == 0 {
+		return nil
+	}
+	return &h.Bids[len(h.Bids)-1]
+}
+
+func (h *Hand) getCurrentBiddingTurn() PlayerId {
+	player := h.StartingPlayer
+	for range h.Calls {
+		player = h.table().Next(player)
+	}
+	return player
+}
+
+// biddingActions returns the pass, every bid higher than the last one and the
+// double or redouble when player may call them.
+func (h *Hand) biddingActions(player PlayerId) []Action {
+	actions := []Action{{Type: BidAction}}
+	var contracts []Contract
+	for _, suit := range suits {
+		contracts = append(contracts, TrumpContract(suit))
+	}
+	contracts = append(contracts, NoTrumpsContract, AllTrumpsContract)
+	for _, points := range bidLevels(h.rules.Mode) {
+		for _, contract := range contracts {
+			bid := Bid{Player: player, Points: points, Contract: contract}
+			if h.checkBid(bid) == nil {
+				actions = append(actions, Action{Type: BidAction, Bid: &bid})
+			}
+		}
+	}
+	if h.canDouble(player) {
+		actions = append(actions, Action{Type: ContraAction, Accept: true})
+	}
+	if h.canRedouble(player) {
+		actions = append(actions, Action{Type: RecontraAction, Accept: true})
+	}
+	return actions
+}
+
+// scoreBid scores a Coinche hand against its contract. Takers who score the
+// points they bid, or take every trick on a capot bid, add the bid to their
+// points. Otherwise the defenders keep their points, declarations included,
+// and share those of the takers and the bid, and the takers keep only their
+// Belote.
+func (h *Hand) scoreBid() {
+	bid := h.lastBid()
+	made := h.Totals[h.TakerTeam] >= bid.Points
+	if bid.IsCapot() {
+		made = h.hasTakenAllTricks(h.TakerTeam)
+	}
+	if made {
+		h.Totals[h.TakerTeam] += bid.Points
+		h.ContractOutcome = ContractMade
+		return
+	}
+
+	kept := h.BelotePoints[h.TakerTeam]
+	h.sharePoints(h.defenders(), h.Totals[h.TakerTeam]-kept+bid.Points)
+	h.Totals[h.TakerTeam] = kept
+	h.ContractOutcome = ContractInside
+}
+
+// roundTotals rounds the totals of a Bulgarian hand to tens: up from a last
+// digit of 6 in a suit contract, of 5 in no trumps and of 4 in all trumps.
+func (h *Hand) roundTotals() {
+	roundUpFrom := map[ContractMode]int{SuitTrumps: 6, NoTrumps: 5, AllTrumps: 4}[h.Trump.Mode]
+	for team, total := range h.Totals {
+		rounded := total / 10 * 10
+		if total%10 >= roundUpFrom {
+			rounded += 10
+		}
+		h.Totals[team] = rounded
+	}
+}
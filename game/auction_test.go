@@ -0,0 +1,296 @@
+package game
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+// startCoinche starts a Coinche game dealt in deck order: Player1 holds the
+// Spades, Player2 the Hearts, Player3 the Diamonds and Player4 the Clubs.
+func startCoinche(t *testing.T) *BeloteGame {
+	t.Helper()
+
+	rules, _ := GetRuleSetPreset(CoincheRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	if state := gm.GetHand().GetState(); state != Bidding {
+		t.Fatalf("expected the hand to start with the auction, got %s", state)
+	}
+	return &gm
+}
+
+func TestCoincheAuction(t *testing.T) {
+	gm := startCoinche(t)
+	hand := gm.GetHand()
+	for _, player := range []PlayerId{Player1, Player2, Player3, Player4} {
+		if hand.GetPlayerCards(player).Len() != NUM_CARDS_PER_PLAYER {
+			t.Errorf("expected player %d to be dealt every card before bidding", player)
+		}
+	}
+
+	if err := gm.Bid(Player2, nil); err == nil {
+		t.Errorf("expected a bid out of turn to be rejected")
+	}
+	if err := gm.Bid(Player1, &Bid{Points: 85, Contract: TrumpContract(Hearts)}); !errors.Is(err, ErrInvalidBid) {
+		t.Errorf("expected %v, got %v", ErrInvalidBid, err)
+	}
+	if err := gm.Bid(Player1, &Bid{Points: 80, Contract: NoTrumpsContract}); err == nil {
+		t.Errorf("expected a contract the rules do not allow to be rejected")
+	}
+	if err := gm.Bid(Player1, &Bid{Player: Player3, Points: 80, Contract: TrumpContract(Hearts)}); err != nil {
+		t.Fatal(err)
+	}
+	if hand.Bids[0].Player != Player1 {
+		t.Errorf("expected the bid to be placed by player 1, got %d", hand.Bids[0].Player)
+	}
+	if err := gm.Bid(Player2, &Bid{Points: 80, Contract: TrumpContract(Spades)}); !errors.Is(err, ErrBidTooLow) {
+		t.Errorf("expected %v, got %v", ErrBidTooLow, err)
+	}
+
+	if err := gm.Bid(Player2, &Bid{Points: 90, Contract: TrumpContract(Spades)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range []PlayerId{Player3, Player4, Player1} {
+		if err := gm.Bid(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if hand.GetState() != HandInProgress {
+		t.Fatalf("expected the hand to start once the bid is passed round, got %s", hand.GetState())
+	}
+	if hand.Taker != Player2 || hand.TakerTeam != Team2 || hand.GetTrump() != TrumpContract(Spades) || hand.Multiplier != 1 {
+		t.Errorf("expected player 2 to take the hand in Spades, got %d in %s", hand.Taker, hand.GetTrump())
+	}
+	if player, _ := hand.GetCurrentTurn(); player != Player1 {
+		t.Errorf("expected the starting player to lead, got %d", player)
+	}
+}
+
+func TestCoincheAndSurcoinche(t *testing.T) {
+	gm := startCoinche(t)
+	hand := gm.GetHand()
+	if err := gm.Bid(Player1, &Bid{Points: 100, Contract: TrumpContract(Spades)}); err != nil {
+		t.Fatal(err)
+	}
+
+	coinche := Action{Type: ContraAction, Accept: true}
+	offered := false
+	for _, action := range gm.LegalActions(Player2) {
+		offered = offered || action == coinche
+	}
+	if !offered {
+		t.Errorf("expected player 2 to be offered the coinche")
+	}
+	if err := gm.Bid(Player2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.CallContra(Player3, true); err == nil {
+		t.Errorf("expected the bidder's partner not to coinche")
+	}
+	if err := gm.Bid(Player3, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gm.CallContra(Player4, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.GetState() != RecontraSelection || hand.Multiplier != 2 || hand.Taker != Player1 {
+		t.Fatalf("expected the coinche to close the auction, got %s with multiplier %d", hand.GetState(), hand.Multiplier)
+	}
+	if err := gm.CallRecontra(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.GetState() != HandInProgress || hand.Multiplier != 4 {
+		t.Errorf("expected the surcoinche to double the hand again, got %s with multiplier %d", hand.GetState(), hand.Multiplier)
+	}
+}
+
+func TestAuctionRedealsWhenAllPass(t *testing.T) {
+	gm := startCoinche(t)
+	for _, player := range []PlayerId{Player1, Player2, Player3, Player4} {
+		if err := gm.Bid(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if gm.GetRedeals() != 1 || gm.GetHand().GetState() != Bidding || len(gm.GetHand().Bids) != 0 {
+		t.Errorf("expected a new auction on a new deal, got %d redeals in %s", gm.GetRedeals(), gm.GetHand().GetState())
+	}
+	events := gm.GetEvents()
+	if _, ok := events[len(events)-2].(HandRedealtEvent); !ok {
+		t.Errorf("expected the redeal to be recorded, got %+v", events[len(events)-2])
+	}
+}
+
+func TestScoreBid(t *testing.T) {
+	testCases := []struct {
+		name            string
+		totals          map[TeamId]int
+		expectedTotals  map[TeamId]int
+		expectedOutcome ContractOutcome
+	}{
+		{
+			name:            "made",
+			totals:          map[TeamId]int{Team1: 60, Team2: 112},
+			expectedTotals:  map[TeamId]int{Team1: 60, Team2: 212},
+			expectedOutcome: ContractMade,
+		},
+		{
+			name:            "failed",
+			totals:          map[TeamId]int{Team1: 90, Team2: 92},
+			expectedTotals:  map[TeamId]int{Team1: 90 + 72 + 100, Team2: 20},
+			expectedOutcome: ContractInside,
+		},
+		{
+			name:            "failed against declarations",
+			totals:          map[TeamId]int{Team1: 140, Team2: 92},
+			expectedTotals:  map[TeamId]int{Team1: 140 + 72 + 100, Team2: 20},
+			expectedOutcome: ContractInside,
+		},
+	}
+
+	rules, _ := GetRuleSetPreset(CoincheRuleSetPreset)
+	for _, tc := range testCases {
+		hand := NewHand(Player1, NewFixedDealer(NewDeck()), rules)
+		hand.Bids = []Bid{{Player: Player2, Points: 100, Contract: TrumpContract(Hearts)}}
+		hand.Taker, hand.TakerTeam = Player2, Team2
+		hand.Totals = tc.totals
+		hand.BelotePoints[Team2] = 20
+
+		hand.scoreContract()
+		if !maps.Equal(hand.Totals, tc.expectedTotals) || hand.ContractOutcome != tc.expectedOutcome {
+			t.Errorf("%s: expected %v (%s), got %v (%s)", tc.name, tc.expectedTotals, tc.expectedOutcome, hand.Totals, hand.ContractOutcome)
+		}
+	}
+}
+
+func TestCapotBid(t *testing.T) {
+	gm := startCoinche(t)
+	if err := gm.Bid(Player1, &Bid{Points: CAPOT_BID, Contract: TrumpContract(Spades)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range []PlayerId{Player2, Player3, Player4} {
+		if err := gm.Bid(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	playHand(t, gm)
+
+	result := gm.GetScoreSheet()[0]
+	if result.Bid == nil || !result.Bid.IsCapot() || result.Outcome != ContractMade {
+		t.Fatalf("expected the capot bid to be made, got %+v", result)
+	}
+	if expected := gm.GetRules().CapotPoints + CAPOT_BID; result.Credited[Team1] != expected || result.Credited[Team2] != 0 {
+		t.Errorf("expected %d for the takers, got %v", expected, result.Credited)
+	}
+}
+
+func TestBulgarianDealsInPackets(t *testing.T) {
+	rules, _ := GetRuleSetPreset(BulgarianRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+
+	deck := NewDeck()
+	expected := NewCardSet(deck[0], deck[1], deck[2], deck[12], deck[13])
+	if cards := gm.GetHand().GetPlayerCards(Player1); cards != expected {
+		t.Errorf("expected packets of three and two, got %v", cards.Cards())
+	}
+}
+
+func TestBulgarianAuction(t *testing.T) {
+	rules, _ := GetRuleSetPreset(BulgarianRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	hand := gm.GetHand()
+	if hand.GetState() != Bidding || hand.GetPlayerCards(Player1).Len() != NUM_CARDS_BEFORE_TRUMP {
+		t.Fatalf("expected the auction to start on %d cards, got %s", NUM_CARDS_BEFORE_TRUMP, hand.GetState())
+	}
+
+	if err := gm.Bid(Player1, &Bid{Points: 80, Contract: TrumpContract(Hearts)}); err == nil {
+		t.Errorf("expected a bid with points to be rejected")
+	}
+	if err := gm.Bid(Player1, &Bid{Contract: TrumpContract(Hearts)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.Bid(Player2, &Bid{Contract: TrumpContract(Diamonds)}); !errors.Is(err, ErrBidTooLow) {
+		t.Errorf("expected Diamonds to rank below Hearts, got %v", err)
+	}
+	if err := gm.CallRecontra(Player2, true); err == nil {
+		t.Errorf("expected no redouble before a double")
+	}
+
+	if err := gm.CallContra(Player2, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.GetState() != Bidding || hand.Multiplier != 2 {
+		t.Fatalf("expected the double to keep the auction open, got %s with multiplier %d", hand.GetState(), hand.Multiplier)
+	}
+	if err := gm.CallRecontra(Player3, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.Multiplier != 4 {
+		t.Errorf("expected the redouble to double the hand again, got %d", hand.Multiplier)
+	}
+
+	if err := gm.Bid(Player4, &Bid{Contract: NoTrumpsContract}); err != nil {
+		t.Fatal(err)
+	}
+	if hand.Multiplier != 1 {
+		t.Errorf("expected a higher bid to cancel the doubles, got %d", hand.Multiplier)
+	}
+	for _, player := range []PlayerId{Player1, Player2, Player3} {
+		if err := gm.Bid(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if hand.GetState() != HandInProgress || hand.Taker != Player4 || hand.GetTrump() != NoTrumpsContract {
+		t.Fatalf("expected player 4 to take the hand in no trumps, got %s", hand.GetState())
+	}
+	for _, player := range []PlayerId{Player1, Player2, Player3, Player4} {
+		if hand.GetPlayerCards(player).Len() != NUM_CARDS_PER_PLAYER {
+			t.Errorf("expected player %d to be dealt the rest of the cards", player)
+		}
+	}
+}
+
+func TestBulgarianTotalsRoundToTens(t *testing.T) {
+	testCases := []struct {
+		contract Contract
+		total    int
+		expected int
+	}{
+		{TrumpContract(Hearts), 86, 90},
+		{TrumpContract(Hearts), 85, 80},
+		{NoTrumpsContract, 85, 90},
+		{NoTrumpsContract, 84, 80},
+		{AllTrumpsContract, 84, 90},
+		{AllTrumpsContract, 83, 80},
+	}
+
+	for _, tc := range testCases {
+		hand := &Hand{Trump: tc.contract, Totals: map[TeamId]int{Team1: tc.total}}
+		hand.roundTotals()
+		if hand.Totals[Team1] != tc.expected {
+			t.Errorf("%s: expected %d to round to %d, got %d", tc.contract, tc.total, tc.expected, hand.Totals[Team1])
+		}
+	}
+}
+
+func TestNotationRoundTripsAuctions(t *testing.T) {
+	for _, preset := range []string{CoincheRuleSetPreset, BulgarianRuleSetPreset} {
+		rules, _ := GetRuleSetPreset(preset)
+		for seed := uint64(0); seed < 5; seed++ {
+			gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(seed))
+			gm.Start()
+			playRandomActions(t, &gm, seed, 500)
+			roundTripNotation(t, &gm, nil)
+
+			if _, err := Replay(gm.GetEvents()); err != nil {
+				t.Errorf("%s, seed %d: %v", preset, seed, err)
+			}
+		}
+	}
+}
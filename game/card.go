@@ -35,6 +35,13 @@ func (c *Card) String() string {
 	return string(c.Rank) + " of " + string(c.Suit)
 }
 
+var suitOrderIndex = map[Suit]int{
+	Spades:   0,
+	Hearts:   1,
+	Diamonds: 2,
+	Clubs:    3,
+}
+
 var naturalOrderIndex = map[Rank]int{
 	Seven: 0,
 	Eight: 1,
@@ -68,45 +75,97 @@ var nonTrumpTrickOrderIndex = map[Rank]int{
 	Ace:   7,
 }
 
+// suitInitials and rankInitials index the suits and ranks by their first
+// byte, which tells each of them apart, so that cards are looked up in arrays
+// rather than maps on the hot paths.
+var (
+	suitInitials = newInitials(suitOrderIndex)
+	rankInitials = newInitials(naturalOrderIndex)
+)
+
+// trickOrders holds the trick order of each rank in natural order, for
+// non-trump and trump cards.
+var trickOrders = newRankTable(nonTrumpTrickOrderIndex, trumpTrickOrderIndex)
+
+func newInitials[K ~string](index map[K]int) [256]uint8 {
+	var initials [256]uint8
+	for key, i := range index {
+		initials[key[0]] = uint8(i)
+	}
+	return initials
+}
+
+func newRankTable(nonTrump, trump map[Rank]int) [2][NUM_CARD_VALUES]int {
+	var table [2][NUM_CARD_VALUES]int
+	for rank, i := range naturalOrderIndex {
+		table[0][i] = nonTrump[rank]
+		table[1][i] = trump[rank]
+	}
+	return table
+}
+
+// suitIndex returns the position of suit in NewDeck.
+func suitIndex(suit Suit) int {
+	if suit == "" {
+		return 0
+	}
+	return int(suitInitials[suit[0]])
+}
+
+// rankIndex returns the natural order of rank.
+func rankIndex(rank Rank) int {
+	if rank == "" {
+		return 0
+	}
+	return int(rankInitials[rank[0]])
+}
+
 func (r *Rank) NaturalOrder() int {
-	return naturalOrderIndex[*r]
+	return rankIndex(*r)
 }
 
 func (r *Rank) TrickOrder(isTrump bool) int {
-	if isTrump {
-		return trumpTrickOrderIndex[*r]
-	}
-	return nonTrumpTrickOrderIndex[*r]
+	return trickOrders[trumpIndex(isTrump)][rankIndex(*r)]
+}
+
+var nonTrumpPoints = map[Rank]int{
+	Seven: 0,
+	Eight: 0,
+	Nine:  0,
+	Jack:  2,
+	Queen: 3,
+	King:  4,
+	Ten:   10,
+	Ace:   11,
+}
+
+var trumpPoints = map[Rank]int{
+	Seven: 0,
+	Eight: 0,
+	Queen: 3,
+	King:  4,
+	Ten:   10,
+	Ace:   11,
+	Nine:  14,
+	Jack:  20,
 }
 
 func (r *Rank) GetNonTrumpPoints() int {
-	points := map[Rank]int{
-		Seven: 0,
-		Eight: 0,
-		Nine:  0,
-		Jack:  2,
-		Queen: 3,
-		King:  4,
-		Ten:   10,
-		Ace:   11,
-	}
-	return points[*r]
+	return cardPoints[0][rankIndex(*r)]
 }
 
 func (r *Rank) GetTrumpPoints() int {
-	points := map[Rank]int{
-		Seven: 0,
-		Eight: 0,
-		Queen: 3,
-		King:  4,
-		Ten:   10,
-		Ace:   11,
-		Nine:  14,
-		Jack:  20,
-	}
-	return points[*r]
+	return cardPoints[1][rankIndex(*r)]
 }
 
 func Less(r1, r2 Rank, isTrump bool) bool {
 	return r1.TrickOrder(isTrump) < r2.TrickOrder(isTrump)
 }
+
+// CompareCards orders cards the way NewDeck does: by suit, then by natural rank.
+func CompareCards(c1, c2 Card) int {
+	if c1.Suit != c2.Suit {
+		return suitIndex(c1.Suit) - suitIndex(c2.Suit)
+	}
+	return c1.Rank.NaturalOrder() - c2.Rank.NaturalOrder()
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// CardSet is a set of cards packed in 32 bits. Each suit takes eight bits,
+// in the order of NewDeck, so bit i is the card NewDeck()[i] and a set lists
+// its cards sorted with CompareCards. CardSets are values: the methods
+// changing a set return the new set.
+type CardSet uint32
+
+const (
+	cardsPerSuitBits = NUM_CARD_VALUES
+	suitBits         = 1<<cardsPerSuitBits - 1
+)
+
+var (
+	// deckCards maps each bit of a CardSet to its card.
+	deckCards = NewDeck()
+
+	// trickOrderBits maps, for non-trump and trump cards, the trick order of
+	// a rank to its bit within a suit, so that the highest card of a suit is
+	// found by walking it in trick order.
+	trickOrderBits = newTrickOrderBits()
+
+	// cardPoints holds the points of each bit of a suit, for non-trump and
+	// trump cards.
+	cardPoints = newRankTable(nonTrumpPoints, trumpPoints)
+)
+
+func newTrickOrderBits() [2][NUM_CARD_VALUES]int {
+	var order [2][NUM_CARD_VALUES]int
+	for i, rank := range ranks {
+		order[0][rank.TrickOrder(false)] = i
+		order[1][rank.TrickOrder(true)] = i
+	}
+	return order
+}
+
+func NewCardSet(cards ...Card) CardSet {
+	var set CardSet
+	for _, card := range cards {
+		set = set.With(card)
+	}
+	return set
+}
+
+func cardBit(card Card) int {
+	return suitIndex(card.Suit)*cardsPerSuitBits + rankIndex(card.Rank)
+}
+
+// SuitCards returns the set of every card of suit.
+func SuitCards(suit Suit) CardSet {
+	return suitBits << (suitIndex(suit) * cardsPerSuitBits)
+}
+
+func (s CardSet) With(card Card) CardSet {
+	return s | 1<<cardBit(card)
+}
+
+func (s CardSet) Without(card Card) CardSet {
+	return s &^ (1 << cardBit(card))
+}
+
+func (s CardSet) Contains(card Card) bool {
+	return s&(1<<cardBit(card)) != 0
+}
+
+func (s CardSet) Union(other CardSet) CardSet {
+	return s | other
+}
+
+func (s CardSet) Intersection(other CardSet) CardSet {
+	return s & other
+}
+
+func (s CardSet) Difference(other CardSet) CardSet {
+	return s &^ other
+}
+
+func (s CardSet) Len() int {
+	return bits.OnesCount32(uint32(s))
+}
+
+func (s CardSet) IsEmpty() bool {
+	return s == 0
+}
+
+// OfSuit returns the cards of the set of suit.
+func (s CardSet) OfSuit(suit Suit) CardSet {
+	return s & SuitCards(suit)
+}
+
+func (s CardSet) HasSuit(suit Suit) bool {
+	return s.OfSuit(suit) != 0
+}
+
+// Cards lists the cards of the set sorted with CompareCards.
+func (s CardSet) Cards() []Card {
+	cards := make([]Card, 0, s.Len())
+	for rest := uint32(s); rest != 0; rest &= rest - 1 {
+		cards = append(cards, deckCards[bits.TrailingZeros32(rest)])
+	}
+	return cards
+}
+
+// Highest returns the card of suit in the set that takes every other one in a
+// trick, with trump telling how the suit is ranked.
+func (s CardSet) Highest(suit Suit, isTrump bool) (Card, bool) {
+	cards := uint32(s) >> (suitIndex(suit) * cardsPerSuitBits) & suitBits
+	if cards == 0 {
+		return Card{}, false
+	}
+
+	order := trickOrderBits[trumpIndex(isTrump)]
+	for i := NUM_CARD_VALUES - 1; i >= 0; i-- {
+		if cards&(1<<order[i]) != 0 {
+			return deckCards[suitIndex(suit)*cardsPerSuitBits+order[i]], true
+		}
+	}
+	panic("unreachable")
+}
+
+// Points returns what the cards of the set are worth in contract.
+func (s CardSet) Points(contract Contract) int {
+	total := 0
+	for rest := uint32(s); rest != 0; rest &= rest - 1 {
+		bit := bits.TrailingZeros32(rest)
+		total += cardPoints[trumpIndex(contract.IsTrump(deckCards[bit].Suit))][bit%cardsPerSuitBits]
+	}
+	return total
+}
+
+func (s CardSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Cards())
+}
+
+func (s *CardSet) UnmarshalJSON(data []byte) error {
+	var cards []Card
+	if err := json.Unmarshal(data, &cards); err != nil {
+		return err
+	}
+	for _, card := range cards {
+		if _, ok := suitOrderIndex[card.Suit]; !ok {
+			return fmt.Errorf("unknown suit %q", card.Suit)
+		}
+		if _, ok := naturalOrderIndex[card.Rank]; !ok {
+			return fmt.Errorf("unknown rank %q", card.Rank)
+		}
+	}
+	*s = NewCardSet(cards...)
+	return nil
+}
+
+func trumpIndex(isTrump bool) int {
+	if isTrump {
+		return 1
+	}
+	return 0
+}
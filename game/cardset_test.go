@@ -0,0 +1,173 @@
+package game
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestCardSetMembership(t *testing.T) {
+	aceOfSpades := Card{Suit: Spades, Rank: Ace}
+	sevenOfClubs := Card{Suit: Clubs, Rank: Seven}
+
+	set := NewCardSet(aceOfSpades)
+	if !set.Contains(aceOfSpades) || set.Contains(sevenOfClubs) {
+		t.Errorf("expected the set to hold only the Ace of Spades")
+	}
+
+	set = set.With(sevenOfClubs).With(sevenOfClubs)
+	if set.Len() != 2 {
+		t.Errorf("expected 2 cards, got %d", set.Len())
+	}
+
+	set = set.Without(aceOfSpades)
+	if set.Contains(aceOfSpades) || !set.Contains(sevenOfClubs) {
+		t.Errorf("expected the Ace of Spades to be removed")
+	}
+	if !set.Without(sevenOfClubs).IsEmpty() {
+		t.Errorf("expected an empty set")
+	}
+}
+
+func TestCardSetCardsFollowDeckOrder(t *testing.T) {
+	deck := NewDeck()
+	full := NewCardSet(deck...)
+	if full.Len() != len(deck) {
+		t.Fatalf("expected %d cards, got %d", len(deck), full.Len())
+	}
+	if !slices.Equal(full.Cards(), deck) {
+		t.Errorf("expected the cards of a full set in deck order")
+	}
+
+	for _, suit := range suits {
+		cards := full.OfSuit(suit).Cards()
+		if len(cards) != NUM_CARD_VALUES {
+			t.Errorf("expected %d cards of %s, got %d", NUM_CARD_VALUES, suit, len(cards))
+		}
+		for _, card := range cards {
+			if card.Suit != suit {
+				t.Errorf("expected only %s, got %v", suit, card)
+			}
+		}
+	}
+}
+
+func TestCardIndexesMatchTheOrders(t *testing.T) {
+	for suit, i := range suitOrderIndex {
+		if suitIndex(suit) != i {
+			t.Errorf("expected %s at %d, got %d", suit, i, suitIndex(suit))
+		}
+	}
+	for rank, i := range naturalOrderIndex {
+		if rank.NaturalOrder() != i {
+			t.Errorf("expected %s at %d, got %d", rank, i, rank.NaturalOrder())
+		}
+		if rank.TrickOrder(false) != nonTrumpTrickOrderIndex[rank] || rank.TrickOrder(true) != trumpTrickOrderIndex[rank] {
+			t.Errorf("unexpected trick orders %d and %d for %s", rank.TrickOrder(false), rank.TrickOrder(true), rank)
+		}
+		if rank.GetNonTrumpPoints() != nonTrumpPoints[rank] || rank.GetTrumpPoints() != trumpPoints[rank] {
+			t.Errorf("unexpected points %d and %d for %s", rank.GetNonTrumpPoints(), rank.GetTrumpPoints(), rank)
+		}
+	}
+}
+
+func TestCardSetHighest(t *testing.T) {
+	set := NewCardSet(
+		Card{Suit: Hearts, Rank: Nine},
+		Card{Suit: Hearts, Rank: Ten},
+		Card{Suit: Hearts, Rank: King},
+	)
+
+	tests := []struct {
+		suit    Suit
+		isTrump bool
+		want    Card
+		ok      bool
+	}{
+		{Hearts, false, Card{Suit: Hearts, Rank: Ten}, true},
+		{Hearts, true, Card{Suit: Hearts, Rank: Nine}, true},
+		{Spades, false, Card{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := set.Highest(test.suit, test.isTrump)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Highest(%s, %t) = %v, %t, expected %v, %t", test.suit, test.isTrump, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestCardSetPoints(t *testing.T) {
+	full := NewCardSet(NewDeck()...)
+	if points := full.Points(TrumpContract(Hearts)); points != 152 {
+		t.Errorf("expected a full deck to be worth 152 points, got %d", points)
+	}
+	if points := full.OfSuit(Hearts).Points(TrumpContract(Hearts)); points != 62 {
+		t.Errorf("expected the trump suit to be worth 62 points, got %d", points)
+	}
+	if points := full.OfSuit(Spades).Points(TrumpContract(Hearts)); points != 30 {
+		t.Errorf("expected a non-trump suit to be worth 30 points, got %d", points)
+	}
+}
+
+func TestCardSetJSONKeepsCardShape(t *testing.T) {
+	cards := []Card{{Suit: Spades, Rank: Jack}, {Suit: Diamonds, Rank: Ace}}
+
+	data, err := json.Marshal(NewCardSet(cards...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := json.Marshal(cards)
+	if string(data) != string(expected) {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var set CardSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		t.Fatal(err)
+	}
+	if set != NewCardSet(cards...) {
+		t.Errorf("expected the set to round trip, got %v", set.Cards())
+	}
+
+	if err := json.Unmarshal([]byte(`[{"suit":"Stars","rank":"A"}]`), &set); err == nil {
+		t.Errorf("expected an unknown suit to be rejected")
+	}
+}
+
+func TestLegalCardSetMatchesValidateCard(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		hand := NewHand(Player1, NewSeededDealer(seed), DefaultRuleSet())
+		for hand.GetState() != HandFinished {
+			player, _ := hand.GetCurrentTurn()
+			if hand.GetState() != HandInProgress {
+				var err error
+				switch hand.GetState() {
+				case TableTrumpSelection:
+					err = hand.AcceptTableTrump(player, true)
+				case ContraSelection:
+					err = hand.CallContra(player, false)
+				case RecontraSelection:
+					err = hand.CallRecontra(player, false)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+
+			cards := hand.GetPlayerCards(player)
+			legal := hand.CurrentTrick.LegalCardSet(cards)
+			for _, card := range cards.Cards() {
+				err := hand.CurrentTrick.validateCard(card, cards)
+				if (err == nil) != legal.Contains(card) {
+					t.Fatalf("seed %d: legal set and validateCard disagree on %v: %v", seed, card, err)
+				}
+			}
+
+			if err := hand.PlayCard(player, legal.Cards()[int(seed)%legal.Len()], Announcement{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
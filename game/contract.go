@@ -0,0 +1,110 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type ContractMode string
+
+const (
+	// SuitTrumps makes one suit trump, the usual contract.
+	SuitTrumps ContractMode = "Suit"
+	// NoTrumps ranks and scores every suit as a non-trump suit. Nothing can
+	// be declared, Belote included.
+	NoTrumps ContractMode = "NoTrumps"
+	// AllTrumps ranks and scores every suit as a trump suit. Belote can be
+	// announced in any suit.
+	AllTrumps ContractMode = "AllTrumps"
+)
+
+// Contract is what a hand is played in: a trump suit, no trumps or all
+// trumps. It is written in JSON as the name of its trump suit or of its mode.
+type Contract struct {
+	Mode ContractMode
+	// Suit is the trump suit of a SuitTrumps contract.
+	Suit Suit
+}
+
+var (
+	NoTrumpsContract  = Contract{Mode: NoTrumps}
+	AllTrumpsContract = Contract{Mode: AllTrumps}
+)
+
+// TrumpContract returns the contract with suit as trump.
+func TrumpContract(suit Suit) Contract {
+	return Contract{Mode: SuitTrumps, Suit: suit}
+}
+
+// TrumpSuit returns the trump suit of a SuitTrumps contract.
+func (c Contract) TrumpSuit() (Suit, bool) {
+	if c.Mode != SuitTrumps {
+		return "", false
+	}
+	return c.Suit, true
+}
+
+// IsTrump tells whether cards of suit rank and score as trumps.
+func (c Contract) IsTrump(suit Suit) bool {
+	switch c.Mode {
+	case AllTrumps:
+		return true
+	case NoTrumps:
+		return false
+	}
+	return suit == c.Suit
+}
+
+// TrickOrder ranks card within its suit.
+func (c Contract) TrickOrder(card Card) int {
+	return card.Rank.TrickOrder(c.IsTrump(card.Suit))
+}
+
+// CardPoints returns what card is worth in a trick.
+func (c Contract) CardPoints(card Card) int {
+	if c.IsTrump(card.Suit) {
+		return card.Rank.GetTrumpPoints()
+	}
+	return card.Rank.GetNonTrumpPoints()
+}
+
+// AllowsDeclarations tells whether pre-hand declarations can be announced.
+func (c Contract) AllowsDeclarations() bool {
+	return c.Mode != NoTrumps
+}
+
+// AllowsBelote tells whether the King and Queen of suit make a Belote.
+func (c Contract) AllowsBelote(suit Suit) bool {
+	return c.Mode == AllTrumps || (c.Mode == SuitTrumps && suit == c.Suit)
+}
+
+func (c Contract) String() string {
+	if c.Mode == SuitTrumps {
+		return string(c.Suit)
+	}
+	return string(c.Mode)
+}
+
+func (c Contract) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Contract) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	switch {
+	case name == string(NoTrumps):
+		*c = NoTrumpsContract
+	case name == string(AllTrumps):
+		*c = AllTrumpsContract
+	default:
+		if _, ok := suitOrderIndex[Suit(name)]; !ok {
+			return fmt.Errorf("unknown contract %q", name)
+		}
+		*c = TrumpContract(Suit(name))
+	}
+	return nil
+}
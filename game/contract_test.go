@@ -0,0 +1,242 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestContractJSON(t *testing.T) {
+	for _, contract := range []Contract{TrumpContract(Clubs), NoTrumpsContract, AllTrumpsContract} {
+		data, err := json.Marshal(contract)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `"`+contract.String()+`"` {
+			t.Errorf("expected %s to be written as its name, got %s", contract, data)
+		}
+
+		var got Contract
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != contract {
+			t.Errorf("expected %s, got %s", contract, got)
+		}
+	}
+
+	var contract Contract
+	if err := json.Unmarshal([]byte(`"Stars"`), &contract); err == nil {
+		t.Errorf("expected an unknown contract to be rejected")
+	}
+}
+
+func TestTrickResultUnderContracts(t *testing.T) {
+	cards := map[PlayerId]Card{
+		Player1: {Suit: Hearts, Rank: Seven},
+		Player2: {Suit: Spades, Rank: Jack},
+		Player3: {Suit: Hearts, Rank: Ace},
+		Player4: {Suit: Hearts, Rank: Nine},
+	}
+
+	testCases := []struct {
+		contract       Contract
+		expectedWinner PlayerId
+		expectedPoints int
+	}{
+		{TrumpContract(Spades), Player2, 31},
+		{TrumpContract(Diamonds), Player3, 13},
+		{NoTrumpsContract, Player3, 13},
+		{AllTrumpsContract, Player4, 45},
+	}
+
+	for _, tc := range testCases {
+		trick := &Trick{StartingPlayer: Player1, Cards: cards, Trump: tc.contract}
+		result, err := trick.GetTrickResult()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.WinnerPlayer != tc.expectedWinner || result.Points != tc.expectedPoints {
+			t.Errorf("%s: expected %d to win %d points, got %d with %d",
+				tc.contract, tc.expectedWinner, tc.expectedPoints, result.WinnerPlayer, result.Points)
+		}
+	}
+}
+
+func TestLegalCardsUnderContracts(t *testing.T) {
+	following := NewCardSet(
+		Card{Suit: Hearts, Rank: Queen},
+		Card{Suit: Hearts, Rank: Nine},
+		Card{Suit: Spades, Rank: Jack},
+	)
+	void := NewCardSet(
+		Card{Suit: Spades, Rank: Jack},
+		Card{Suit: Clubs, Rank: Seven},
+	)
+
+	testCases := []struct {
+		name        string
+		contract    Contract
+		playerCards CardSet
+		expected    CardSet
+	}{
+		{
+			name:        "no trumps follows suit at any height",
+			contract:    NoTrumpsContract,
+			playerCards: following,
+			expected:    NewCardSet(Card{Suit: Hearts, Rank: Queen}, Card{Suit: Hearts, Rank: Nine}),
+		},
+		{
+			name:        "all trumps must go higher in the lead suit",
+			contract:    AllTrumpsContract,
+			playerCards: following,
+			expected:    NewCardSet(Card{Suit: Hearts, Rank: Nine}),
+		},
+		{
+			name:        "suit contract must ruff",
+			contract:    TrumpContract(Spades),
+			playerCards: void,
+			expected:    NewCardSet(Card{Suit: Spades, Rank: Jack}),
+		},
+		{
+			name:        "no trumps discards anything",
+			contract:    NoTrumpsContract,
+			playerCards: void,
+			expected:    void,
+		},
+		{
+			name:        "all trumps discards anything",
+			contract:    AllTrumpsContract,
+			playerCards: void,
+			expected:    void,
+		},
+	}
+
+	for _, tc := range testCases {
+		trick := &Trick{
+			StartingPlayer: Player1,
+			Cards:          map[PlayerId]Card{Player1: {Suit: Hearts, Rank: King}},
+			Trump:          tc.contract,
+		}
+		if got := trick.LegalCardSet(tc.playerCards); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected.Cards(), got.Cards())
+		}
+	}
+}
+
+// startFreeTrumpSelection starts a game with a fixed deal and has every
+// player pass on the table trump card.
+func startFreeTrumpSelection(t *testing.T, rules RuleSet) *BeloteGame {
+	t.Helper()
+
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	for range NUM_PLAYERS {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.AcceptTableTrump(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &gm
+}
+
+func TestSelectContractFollowsRules(t *testing.T) {
+	gm := startFreeTrumpSelection(t, DefaultRuleSet())
+	player, _ := gm.GetHand().GetCurrentTurn()
+	for _, contract := range []Contract{NoTrumpsContract, AllTrumpsContract} {
+		if err := gm.SelectContract(player, contract); err == nil {
+			t.Errorf("expected %s to be rejected by the default rules", contract)
+		}
+	}
+	for _, action := range gm.LegalActions(player) {
+		if action.Type == SelectContractAction {
+			t.Errorf("expected no contract to be offered, got %s", action.Contract)
+		}
+	}
+
+	rules := DefaultRuleSet()
+	rules.NoTrumpsAllowed = true
+	gm = startFreeTrumpSelection(t, rules)
+	player, _ = gm.GetHand().GetCurrentTurn()
+
+	offered := false
+	for _, action := range gm.LegalActions(player) {
+		if action.Type == SelectContractAction {
+			offered = offered || *action.Contract == NoTrumpsContract
+			if *action.Contract == AllTrumpsContract {
+				t.Errorf("expected all trumps not to be offered")
+			}
+		}
+	}
+	if !offered {
+		t.Errorf("expected no trumps to be offered")
+	}
+
+	if err := gm.PlayAction(player, Action{Type: SelectContractAction, Contract: &NoTrumpsContract}); err != nil {
+		t.Fatal(err)
+	}
+	if gm.GetHand().GetTrump() != NoTrumpsContract || gm.GetHand().TakerTeam != player.GetTeam() {
+		t.Errorf("expected %d to take the hand in no trumps, got %s", player, gm.GetHand().GetTrump())
+	}
+	events := gm.GetEvents()
+	if selected, ok := events[len(events)-1].(TrumpSelectedEvent); !ok || selected.Contract == nil || *selected.Contract != NoTrumpsContract {
+		t.Errorf("expected the contract to be recorded, got %+v", events[len(events)-1])
+	}
+}
+
+func TestNoDeclarationsWithoutTrumps(t *testing.T) {
+	hand := newAnnouncementHand()
+	hand.Trump = NoTrumpsContract
+	hand.CurrentTrick.Trump = NoTrumpsContract
+
+	if got := hand.BestAnnouncement(Player1, Card{Suit: Hearts, Rank: Queen}); len(got.Declarations) != 0 || got.Belote {
+		t.Errorf("expected nothing to announce in no trumps, got %+v", got)
+	}
+
+	announcement := Announcement{Declarations: []PreHandDeclaration{announcedTierce}}
+	if err := hand.PlayCard(Player1, Card{Suit: Clubs, Rank: Jack}, announcement); !errors.Is(err, ErrDeclarationNotHeld) {
+		t.Errorf("expected %v, got %v", ErrDeclarationNotHeld, err)
+	}
+}
+
+func TestBeloteInAnySuitUnderAllTrumps(t *testing.T) {
+	hand := newAnnouncementHand()
+	if got := hand.BestAnnouncement(Player1, Card{Suit: Spades, Rank: Queen}); got.Belote {
+		t.Errorf("expected no Belote outside the trump suit")
+	}
+
+	hand.Trump = AllTrumpsContract
+	hand.CurrentTrick.Trump = AllTrumpsContract
+	if err := hand.PlayCard(Player1, Card{Suit: Spades, Rank: Queen}, Announcement{Belote: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(hand.PlayerDeclarations[Player1]) != 1 || hand.PlayerDeclarations[Player1][0] != (Belote{}) {
+		t.Errorf("expected Belote in Spades to be announced, got %+v", hand.PlayerDeclarations[Player1])
+	}
+}
+
+func TestNotationRoundTripsContracts(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.NoTrumpsAllowed = true
+	rules.AllTrumpsAllowed = true
+
+	for _, contract := range []Contract{NoTrumpsContract, AllTrumpsContract} {
+		gm := startFreeTrumpSelection(t, rules)
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.SelectContract(player, contract); err != nil {
+			t.Fatal(err)
+		}
+		playRandomActions(t, gm, 3, 60)
+
+		var buf bytes.Buffer
+		if err := WriteNotation(&buf, gm, nil); err != nil {
+			t.Fatal(err)
+		}
+		if name := notationContracts[contract.Mode]; !strings.Contains(buf.String(), formatPlayer(player)+" "+name) {
+			t.Errorf("expected %s to be written as %s, got\n%s", contract, name, buf.String())
+		}
+		roundTripNotation(t, gm, nil)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"math/rand/v2"
 )
 
+// DealerFactory creates the dealer used for each new hand of a game.
+type DealerFactory func() Dealer
+
 type RandomDealer struct {
 	deck []Card
 	cur  int
@@ -12,13 +15,49 @@ type RandomDealer struct {
 
 const MAX_DECK_SIZE = NUM_SUITS * NUM_CARD_VALUES
 
+// MIN_CUT is the fewest cards a cut leaves on either side.
+const MIN_CUT = 3
+
+var ErrInvalidCut = fmt.Errorf("a cut leaves at least %d cards on either side", MIN_CUT)
+
+var (
+	suits = []Suit{Spades, Hearts, Diamonds, Clubs}
+	ranks = []Rank{Seven, Eight, Nine, Ten, Jack, Queen, King, Ace}
+)
+
 func NewRandomDealer() *RandomDealer {
 	return &RandomDealer{
-		deck: shuffleDeck(),
+		deck: shuffleDeck(rand.Perm),
 		cur:  0,
 	}
 }
 
+// NewSeededDealer returns a dealer whose deck order is fully determined by seed.
+func NewSeededDealer(seed uint64) *RandomDealer {
+	return &RandomDealer{
+		deck: shuffleDeck(newSeededRand(seed).Perm),
+		cur:  0,
+	}
+}
+
+func NewRandomDealerFactory() DealerFactory {
+	return func() Dealer {
+		return NewRandomDealer()
+	}
+}
+
+// NewSeededDealerFactory returns a factory producing a reproducible sequence of
+// decks: two games using factories with the same seed get the same deals.
+func NewSeededDealerFactory(seed uint64) DealerFactory {
+	rng := newSeededRand(seed)
+	return func() Dealer {
+		return &RandomDealer{
+			deck: shuffleDeck(rng.Perm),
+			cur:  0,
+		}
+	}
+}
+
 func (d *RandomDealer) DealCard() (Card, error) {
 	if d.cur >= MAX_DECK_SIZE {
 		return Card{}, fmt.Errorf("deck is empty")
@@ -28,19 +67,106 @@ func (d *RandomDealer) DealCard() (Card, error) {
 	return d.deck[d.cur], nil
 }
 
-func shuffleDeck() []Card {
-	deck := make([]Card, MAX_DECK_SIZE)
-	perm := rand.Perm(MAX_DECK_SIZE)
+// FixedDealer deals a predefined sequence of cards, in order.
+type FixedDealer struct {
+	deck []Card
+	cur  int
+}
+
+func NewFixedDealer(cards []Card) *FixedDealer {
+	return &FixedDealer{
+		deck: append([]Card(nil), cards...),
+		cur:  0,
+	}
+}
 
-	suits := []Suit{Spades, Hearts, Diamonds, Clubs}
-	values := []Rank{Seven, Eight, Nine, Ten, Jack, Queen, King, Ace}
+// NewFixedDealerFactory returns a factory that replays the given deals one per
+// hand. Once every deal has been used it starts over from the first one.
+func NewFixedDealerFactory(deals ...[]Card) DealerFactory {
+	next := 0
+	return func() Dealer {
+		if len(deals) == 0 {
+			return NewFixedDealer(nil)
+		}
+
+		defer func() { next = (next + 1) % len(deals) }()
+		return NewFixedDealer(deals[next])
+	}
+}
 
-	for i, v := range perm {
+func (d *FixedDealer) DealCard() (Card, error) {
+	if d.cur >= len(d.deck) {
+		return Card{}, fmt.Errorf("deck is empty")
+	}
+
+	defer func() { d.cur = d.cur + 1 }()
+	return d.deck[d.cur], nil
+}
+
+// NewDeck returns all 32 cards in a fixed order: suit by suit, ranks ascending.
+func NewDeck() []Card {
+	deck := make([]Card, 0, MAX_DECK_SIZE)
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			deck = append(deck, Card{Suit: suit, Rank: rank})
+		}
+	}
+	return deck
+}
+
+func newSeededRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func shuffleDeck(perm func(n int) []int) []Card {
+	deck := make([]Card, MAX_DECK_SIZE)
+
+	for i, v := range perm(MAX_DECK_SIZE) {
 		deck[i] = Card{
 			Suit: suits[v%NUM_SUITS],
-			Rank: values[v/NUM_SUITS],
+			Rank: ranks[v/NUM_SUITS],
 		}
 	}
 
 	return deck
 }
+
+// cutDeck lifts position cards from the top of deck and puts them under the
+// rest.
+func cutDeck(deck []Card, position int) []Card {
+	cut := make([]Card, 0, len(deck))
+	cut = append(cut, deck[position:]...)
+	return append(cut, deck[:position]...)
+}
+
+// collectCards gathers the cards of hand, dealt from deck, for the next deal:
+// the tricks in the order they were played, each in playing order, then the
+// cards every player still holds, in seat order, then the cards never dealt.
+// The cards of a player and those never dealt keep the order of deck.
+func collectCards(hand *Hand, deck []Card) []Card {
+	collected := make([]Card, 0, len(deck))
+	var gathered CardSet
+	gather := func(card Card) {
+		collected = append(collected, card)
+		gathered = gathered.With(card)
+	}
+
+	for _, trick := range hand.CompletedTricks {
+		for _, player := range trick.Table.PlayerIdsFrom(trick.StartingPlayer) {
+			gather(trick.Cards[player])
+		}
+	}
+	for _, player := range hand.table().PlayerIds() {
+		for _, card := range deck {
+			if hand.PlayerCards[player].Contains(card) {
+				gather(card)
+			}
+		}
+	}
+	for _, card := range deck {
+		if !gathered.Contains(card) {
+			gather(card)
+		}
+	}
+	return collected
+}
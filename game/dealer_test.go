@@ -0,0 +1,101 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func dealAll(d Dealer) []Card {
+	var cards []Card
+	for {
+		card, err := d.DealCard()
+		if err != nil {
+			return cards
+		}
+		cards = append(cards, card)
+	}
+}
+
+func TestSeededDealerIsReproducible(t *testing.T) {
+	first := dealAll(NewSeededDealer(42))
+	second := dealAll(NewSeededDealer(42))
+
+	if len(first) != MAX_DECK_SIZE {
+		t.Fatalf("expected %d cards, got %d", MAX_DECK_SIZE, len(first))
+	}
+	if !slices.Equal(first, second) {
+		t.Errorf("expected identical decks for the same seed")
+	}
+	if slices.Equal(first, dealAll(NewSeededDealer(43))) {
+		t.Errorf("expected different decks for different seeds")
+	}
+}
+
+func TestSeededDealerDealsEveryCardOnce(t *testing.T) {
+	seen := map[Card]bool{}
+	for _, card := range dealAll(NewSeededDealer(7)) {
+		if seen[card] {
+			t.Fatalf("card %v dealt twice", card)
+		}
+		seen[card] = true
+	}
+	if len(seen) != MAX_DECK_SIZE {
+		t.Errorf("expected %d distinct cards, got %d", MAX_DECK_SIZE, len(seen))
+	}
+}
+
+func TestSeededDealerFactoryIsReproducible(t *testing.T) {
+	f1 := NewSeededDealerFactory(1)
+	f2 := NewSeededDealerFactory(1)
+
+	firstHand := dealAll(f1())
+	if !slices.Equal(firstHand, dealAll(f2())) {
+		t.Errorf("expected identical first deals")
+	}
+	if !slices.Equal(dealAll(f1()), dealAll(f2())) {
+		t.Errorf("expected identical second deals")
+	}
+	if slices.Equal(firstHand, dealAll(f1())) {
+		t.Errorf("expected consecutive deals to differ")
+	}
+}
+
+func TestFixedDealerFactoryCyclesDeals(t *testing.T) {
+	a := []Card{{Suit: Spades, Rank: Ace}}
+	b := []Card{{Suit: Hearts, Rank: Ten}}
+	factory := NewFixedDealerFactory(a, b)
+
+	for i, expected := range [][]Card{a, b, a} {
+		if got := dealAll(factory()); !slices.Equal(got, expected) {
+			t.Errorf("deal %d: expected %v, got %v", i, expected, got)
+		}
+	}
+}
+
+func TestCutDeck(t *testing.T) {
+	deck := NewDeck()
+	cut := cutDeck(deck, 10)
+	if !slices.Equal(cut[:22], deck[10:]) || !slices.Equal(cut[22:], deck[:10]) {
+		t.Errorf("expected the lifted cards under the rest, got %v", cut)
+	}
+}
+
+func TestCollectCards(t *testing.T) {
+	deck := NewDeck()
+	trick := NewTrick(Player3, TrumpContract(Hearts), FourPlayerTable)
+	trick.Cards = map[PlayerId]Card{Player1: deck[7], Player2: deck[20], Player3: deck[3], Player4: deck[0]}
+	hand := &Hand{
+		CompletedTricks: []*Trick{trick},
+		PlayerCards:     map[PlayerId]CardSet{Player1: NewCardSet(deck[9]), Player2: NewCardSet(deck[5], deck[1])},
+		rules:           DefaultRuleSet(),
+	}
+
+	collected := collectCards(hand, deck)
+	expected := []Card{deck[3], deck[0], deck[7], deck[20], deck[9], deck[1], deck[5], deck[2], deck[4], deck[6]}
+	if !slices.Equal(collected[:len(expected)], expected) {
+		t.Errorf("expected the trick in playing order, the held cards in seat order, then the rest in deck order, got %v", collected[:len(expected)])
+	}
+	if len(collected) != MAX_DECK_SIZE || NewCardSet(collected...).Len() != MAX_DECK_SIZE {
+		t.Errorf("expected every card to be collected once, got %v", collected)
+	}
+}
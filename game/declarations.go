@@ -1,12 +1,13 @@
 package game
 
-import "sort"
+import (
+	"slices"
+	"sort"
+)
 
 func FindPreHandDeclarations(cards []Card) []PreHandDeclaration {
-	var result []PreHandDeclaration
-	result = append(result, findSequences(cards)...)
-	result = append(result, findCarres(cards)...)
-	return result
+	rules := DefaultRuleSet()
+	return rules.FindPreHandDeclarations(cards)
 }
 
 func findSequences(cards []Card) []PreHandDeclaration {
@@ -44,7 +45,7 @@ func findSequences(cards []Card) []PreHandDeclaration {
 	return result
 }
 
-func findCarres(cards []Card) []PreHandDeclaration {
+func findCarres(cards []Card, carreRanks []Rank) []PreHandDeclaration {
 	countByRank := map[Rank]int{}
 	firstByRank := map[Rank]Card{}
 	for _, c := range cards {
@@ -57,10 +58,12 @@ func findCarres(cards []Card) []PreHandDeclaration {
 	var result []PreHandDeclaration
 	for rank, count := range countByRank {
 		if count == 4 {
+			if !slices.Contains(carreRanks, rank) {
+				continue
+			}
+
 			var declType PreHandDeclarationType
 			switch rank {
-			case Seven, Eight:
-				continue
 			case Jack:
 				declType = JacksCarre
 			case Nine:
@@ -88,3 +91,45 @@ func HasBelote(cards []Card, trump Suit) bool {
 	}
 	return hasKing && hasQueen
 }
+
+// DeclarationWinner returns the team holding the best pre-hand declaration
+// among declarations, nil when nobody declared one. Between declarations of
+// equal strength, one in the trump suit of contract wins, then the one
+// declared first from startingPlayer, going round table.
+func DeclarationWinner(declarations map[PlayerId][]Declaration, startingPlayer PlayerId, contract Contract, table Table) *TeamId {
+	// Only a suit contract has a trump suit to break ties with.
+	trump, _ := contract.TrumpSuit()
+	bestByPlayer := map[PlayerId]*PreHandDeclaration{}
+
+	for player, decls := range declarations {
+		for _, decl := range decls {
+			prehand, ok := decl.(PreHandDeclaration)
+			if !ok {
+				continue
+			}
+			if best := bestByPlayer[player]; best == nil || CompareDeclarations(prehand, *best, trump) > 0 {
+				r := prehand
+				bestByPlayer[player] = &r
+			}
+		}
+	}
+
+	var winnerPlayer *PlayerId
+	playerId := startingPlayer
+	for range table.Players() {
+		best := bestByPlayer[playerId]
+		if best != nil {
+			if winnerPlayer == nil || CompareDeclarations(*best, *bestByPlayer[*winnerPlayer], trump) > 0 {
+				p := playerId
+				winnerPlayer = &p
+			}
+		}
+		playerId = table.Next(playerId)
+	}
+
+	if winnerPlayer == nil {
+		return nil
+	}
+	winner := table.Team(*winnerPlayer)
+	return &winner
+}
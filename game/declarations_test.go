@@ -264,3 +264,40 @@ func TestSequenceAndCarreInSameHand(t *testing.T) {
 		t.Errorf("expected JacksCarre highest card Jack, got %+v", jacksCarre.HighestCard)
 	}
 }
+
+func TestDeclarationWinner(t *testing.T) {
+	tierce := func(suit Suit, rank Rank) Declaration {
+		return PreHandDeclaration{Type: Tierce, HighestCard: Card{Suit: suit, Rank: rank}}
+	}
+
+	tests := []struct {
+		name         string
+		declarations map[PlayerId][]Declaration
+		expected     TeamId
+	}{
+		{"nobody declared", map[PlayerId][]Declaration{Player1: {Belote{}}}, NoTeamId},
+		{"higher card wins", map[PlayerId][]Declaration{
+			Player1: {tierce(Spades, Ten)},
+			Player2: {tierce(Clubs, King)},
+		}, Team2},
+		{"trump wins", map[PlayerId][]Declaration{
+			Player1: {tierce(Hearts, Nine)},
+			Player2: {tierce(Clubs, King)},
+		}, Team1},
+		{"first from starting player wins a tie", map[PlayerId][]Declaration{
+			Player1: {tierce(Spades, King)},
+			Player4: {tierce(Clubs, King)},
+		}, Team2},
+	}
+
+	for _, test := range tests {
+		winner := DeclarationWinner(test.declarations, Player3, TrumpContract(Hearts), FourPlayerTable)
+		got := NoTeamId
+		if winner != nil {
+			got = *winner
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected team %d, got %d", test.name, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type GameEventType string
+
+const (
+	GameStartedEventType             GameEventType = "GameStarted"
+	DeckShuffledEventType            GameEventType = "DeckShuffled"
+	DeckCutEventType                 GameEventType = "DeckCut"
+	HandDealtEventType               GameEventType = "HandDealt"
+	HandRedealtEventType             GameEventType = "HandRedealt"
+	TableTrumpAnsweredEventType      GameEventType = "TableTrumpAnswered"
+	TrumpSelectedEventType           GameEventType = "TrumpSelected"
+	BidPlacedEventType               GameEventType = "BidPlaced"
+	ContraAnsweredEventType          GameEventType = "ContraAnswered"
+	RecontraAnsweredEventType        GameEventType = "RecontraAnswered"
+	CardPlayedEventType              GameEventType = "CardPlayed"
+	TrickCompletedEventType          GameEventType = "TrickCompleted"
+	HandCompletedEventType           GameEventType = "HandCompleted"
+	HandSummaryAcknowledgedEventType GameEventType = "HandSummaryAcknowledged"
+	HandSummaryEndedEventType        GameEventType = "HandSummaryEnded"
+)
+
+// GameEvent is an entry of the ordered log a BeloteGame keeps of everything
+// that happened in it. Player actions are recorded together with the events
+// they caused, so the log alone is enough to rebuild the game with Replay.
+type GameEvent interface {
+	Type() GameEventType
+}
+
+type GameStartedEvent struct {
+	StartingPlayer PlayerId `json:"startingPlayer"`
+	Rules          RuleSet  `json:"rules"`
+}
+
+// DeckShuffledEvent records the deck shuffled for the first hand of a game
+// with realistic dealing, before it is cut.
+type DeckShuffledEvent struct {
+	Deck []Card `json:"deck"`
+}
+
+// DeckCutEvent records the cut of the deck a hand is about to be dealt from
+// with realistic dealing.
+type DeckCutEvent struct {
+	Player   PlayerId `json:"player"`
+	Position int      `json:"position"`
+}
+
+// HandDealtEvent records the full deck, in the order it was dealt, of a new hand.
+type HandDealtEvent struct {
+	HandNumber     int      `json:"handNumber"`
+	StartingPlayer PlayerId `json:"startingPlayer"`
+	Deck           []Card   `json:"deck"`
+}
+
+// HandRedealtEvent records that every player passed on trump, or in the
+// auction. It is followed by the new deal of the same hand.
+type HandRedealtEvent struct {
+	HandNumber     int      `json:"handNumber"`
+	StartingPlayer PlayerId `json:"startingPlayer"`
+}
+
+type TableTrumpAnsweredEvent struct {
+	Player   PlayerId `json:"player"`
+	Accepted bool     `json:"accepted"`
+}
+
+// TrumpSelectedEvent records a free trump selection. Contract is set instead
+// of Suit for no-trumps and all-trumps contracts, and both are nil on a pass.
+type TrumpSelectedEvent struct {
+	Player   PlayerId  `json:"player"`
+	Suit     *Suit     `json:"suit,omitempty"`
+	Contract *Contract `json:"contract,omitempty"`
+}
+
+// BidPlacedEvent records a call of the Coinche auction. Bid is nil on a pass.
+// A coinche is recorded as a called contra.
+type BidPlacedEvent struct {
+	Player PlayerId `json:"player"`
+	Bid    *Bid     `json:"bid,omitempty"`
+}
+
+type ContraAnsweredEvent struct {
+	Player PlayerId `json:"player"`
+	Called bool     `json:"called"`
+}
+
+type RecontraAnsweredEvent struct {
+	Player PlayerId `json:"player"`
+	Called bool     `json:"called"`
+}
+
+type CardPlayedEvent struct {
+	Player       PlayerId     `json:"player"`
+	Card         Card         `json:"card"`
+	Announcement Announcement `json:"announcement"`
+}
+
+type TrickCompletedEvent struct {
+	Winner PlayerId `json:"winner"`
+	Points int      `json:"points"`
+}
+
+type HandCompletedEvent struct {
+	Result HandResult `json:"result"`
+}
+
+type HandSummaryAcknowledgedEvent struct {
+	Player PlayerId `json:"player"`
+}
+
+// HandSummaryEndedEvent records the end of a hand summary. Forced is set when
+// the summary ended before every player acknowledged it.
+type HandSummaryEndedEvent struct {
+	Forced bool `json:"forced"`
+}
+
+func (GameStartedEvent) Type() GameEventType             { return GameStartedEventType }
+func (DeckShuffledEvent) Type() GameEventType            { return DeckShuffledEventType }
+func (DeckCutEvent) Type() GameEventType                 { return DeckCutEventType }
+func (HandDealtEvent) Type() GameEventType               { return HandDealtEventType }
+func (HandRedealtEvent) Type() GameEventType             { return HandRedealtEventType }
+func (TableTrumpAnsweredEvent) Type() GameEventType      { return TableTrumpAnsweredEventType }
+func (TrumpSelectedEvent) Type() GameEventType           { return TrumpSelectedEventType }
+func (BidPlacedEvent) Type() GameEventType               { return BidPlacedEventType }
+func (ContraAnsweredEvent) Type() GameEventType          { return ContraAnsweredEventType }
+func (RecontraAnsweredEvent) Type() GameEventType        { return RecontraAnsweredEventType }
+func (CardPlayedEvent) Type() GameEventType              { return CardPlayedEventType }
+func (TrickCompletedEvent) Type() GameEventType          { return TrickCompletedEventType }
+func (HandCompletedEvent) Type() GameEventType           { return HandCompletedEventType }
+func (HandSummaryAcknowledgedEvent) Type() GameEventType { return HandSummaryAcknowledgedEventType }
+func (HandSummaryEndedEvent) Type() GameEventType        { return HandSummaryEndedEventType }
+
+// Replay rebuilds a game from its event log. Player actions are applied in
+// order and every event the rebuilt game produces must match the log exactly.
+func Replay(events []GameEvent) (*BeloteGame, error) {
+	gm, err := newReplayGame(events)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	for i, event := range events {
+		if err := gm.replayEvent(event); err != nil {
+			return nil, fmt.Errorf("replay: event %d (%s): %w", i, event.Type(), err)
+		}
+	}
+
+	if !reflect.DeepEqual(gm.events, events) {
+		return nil, fmt.Errorf("replay: rebuilt game does not match the event log")
+	}
+
+	return gm, nil
+}
+
+// newReplayGame starts the game events were recorded from, ready for the
+// events to be replayed on it.
+func newReplayGame(events []GameEvent) (*BeloteGame, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("event log is empty")
+	}
+
+	started, ok := events[0].(GameStartedEvent)
+	if !ok {
+		return nil, fmt.Errorf("expected %s event first, got %s", GameStartedEventType, events[0].Type())
+	}
+
+	var decks [][]Card
+	for _, event := range events {
+		switch e := event.(type) {
+		case HandDealtEvent:
+			if !started.Rules.RealisticDealing {
+				decks = append(decks, e.Deck)
+			}
+		case DeckShuffledEvent:
+			decks = append(decks, e.Deck)
+		}
+	}
+
+	gm := NewBeloteGameWithDealer(started.Rules, newReplayDealerFactory(decks))
+	gm.startingPlayer = started.StartingPlayer
+	gm.Start()
+	return &gm, nil
+}
+
+func (gm *BeloteGame) replayEvent(event GameEvent) error {
+	switch e := event.(type) {
+	case DeckCutEvent:
+		return gm.Cut(e.Player, e.Position)
+	case TableTrumpAnsweredEvent:
+		return gm.AcceptTableTrump(e.Player, e.Accepted)
+	case TrumpSelectedEvent:
+		if e.Contract != nil {
+			return gm.SelectContract(e.Player, *e.Contract)
+		}
+		return gm.SelectTrump(e.Player, e.Suit)
+	case BidPlacedEvent:
+		return gm.Bid(e.Player, e.Bid)
+	case ContraAnsweredEvent:
+		return gm.CallContra(e.Player, e.Called)
+	case RecontraAnsweredEvent:
+		return gm.CallRecontra(e.Player, e.Called)
+	case CardPlayedEvent:
+		return gm.PlayCard(e.Player, e.Card, e.Announcement)
+	case HandSummaryAcknowledgedEvent:
+		return gm.Continue(e.Player)
+	case HandSummaryEndedEvent:
+		if e.Forced {
+			return gm.EndHandSummary()
+		}
+	}
+	return nil
+}
+
+// newReplayDealerFactory deals the recorded decks in order. Should the log ask
+// for more hands than it recorded, a random deck is dealt and the mismatch is
+// reported when the rebuilt log is compared with the original one.
+func newReplayDealerFactory(decks [][]Card) DealerFactory {
+	next := 0
+	return func() Dealer {
+		if next >= len(decks) {
+			return NewRandomDealer()
+		}
+
+		defer func() { next++ }()
+		return NewFixedDealer(decks[next])
+	}
+}
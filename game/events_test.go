@@ -0,0 +1,156 @@
+package game
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+// playGameWithFreeTrumps plays a game in which the table trump is always
+// refused and the second player of the free round picks the trump.
+func playGameWithFreeTrumps(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	gm.Start()
+	for moves := 0; gm.GetState() != GameFinished; moves++ {
+		if moves > 10000 {
+			t.Fatal("game did not finish")
+		}
+		if gm.GetState() == GameHandSummary {
+			continueToNextHand(t, gm)
+			continue
+		}
+
+		hand := gm.GetHand()
+		player, _ := hand.GetCurrentTurn()
+		switch hand.GetState() {
+		case TableTrumpSelection:
+			if err := gm.AcceptTableTrump(player, false); err != nil {
+				t.Fatal(err)
+			}
+		case FreeTrumpSelection:
+			var suit *Suit
+			if player != hand.StartingPlayer {
+				for _, s := range suits {
+					if s != hand.GetTableTrump().Suit {
+						suit = &s
+						break
+					}
+				}
+			}
+			if err := gm.SelectTrump(player, suit); err != nil {
+				t.Fatal(err)
+			}
+		case HandInProgress:
+			playFirstValidCard(t, gm)
+		}
+	}
+}
+
+func TestEventLogRecordsEveryAction(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(5))
+	playGameWithFreeTrumps(t, &gm)
+
+	counts := map[GameEventType]int{}
+	for _, event := range gm.GetEvents() {
+		counts[event.Type()]++
+	}
+
+	if counts[GameStartedEventType] != 1 {
+		t.Errorf("expected one GameStarted event, got %d", counts[GameStartedEventType])
+	}
+	hands := counts[HandDealtEventType]
+	if hands == 0 || counts[HandCompletedEventType] != hands {
+		t.Errorf("expected every dealt hand to complete, got %d dealt and %d completed", hands, counts[HandCompletedEventType])
+	}
+	if counts[CardPlayedEventType] != hands*NUM_PLAYERS*NUM_CARDS_PER_PLAYER {
+		t.Errorf("expected %d played cards, got %d", hands*NUM_PLAYERS*NUM_CARDS_PER_PLAYER, counts[CardPlayedEventType])
+	}
+	if counts[TrickCompletedEventType] != hands*NUM_CARDS_PER_PLAYER {
+		t.Errorf("expected %d tricks, got %d", hands*NUM_CARDS_PER_PLAYER, counts[TrickCompletedEventType])
+	}
+	if counts[TrumpSelectedEventType] == 0 {
+		t.Errorf("expected free trump selections to be recorded")
+	}
+
+	last := gm.GetEvents()[len(gm.GetEvents())-1].(HandCompletedEvent)
+	if !maps.Equal(last.Result.Scores, gm.GetScores()) {
+		t.Errorf("expected last hand to record final scores %v, got %v", gm.GetScores(), last.Result.Scores)
+	}
+}
+
+func TestReplayRebuildsFinishedGame(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(11))
+	playGameWithFreeTrumps(t, &gm)
+
+	replayed, err := Replay(gm.GetEvents())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed.GetState() != GameFinished {
+		t.Errorf("expected replayed game to be finished, got %s", replayed.GetState())
+	}
+	if !maps.Equal(replayed.GetScores(), gm.GetScores()) {
+		t.Errorf("expected scores %v, got %v", gm.GetScores(), replayed.GetScores())
+	}
+	if !reflect.DeepEqual(replayed.GetEvents(), gm.GetEvents()) {
+		t.Errorf("expected identical event logs")
+	}
+}
+
+func TestReplayRebuildsGameInProgress(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(3))
+	gm.Start()
+	if gm.GetHand().GetState() == TableTrumpSelection {
+		if err := gm.AcceptTableTrump(gm.GetHand().StartingPlayer, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 6; i++ {
+		playFirstValidCard(t, &gm)
+	}
+
+	replayed, err := Replay(gm.GetEvents())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original, rebuilt := gm.GetHand(), replayed.GetHand()
+	if !reflect.DeepEqual(original.PlayerCards, rebuilt.PlayerCards) {
+		t.Errorf("expected identical player cards")
+	}
+	if !reflect.DeepEqual(original.CurrentTrick, rebuilt.CurrentTrick) {
+		t.Errorf("expected identical current trick")
+	}
+	if !maps.Equal(original.Totals, rebuilt.Totals) {
+		t.Errorf("expected totals %v, got %v", original.Totals, rebuilt.Totals)
+	}
+}
+
+func TestReplayRejectsTamperedLog(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(9))
+	playGame(t, &gm)
+
+	events := gm.GetEvents()
+	for i, event := range events {
+		if trick, ok := event.(TrickCompletedEvent); ok {
+			trick.Points++
+			events[i] = trick
+			break
+		}
+	}
+
+	if _, err := Replay(events); err == nil {
+		t.Errorf("expected tampered log to be rejected")
+	}
+}
+
+func TestReplayRejectsLogWithoutStart(t *testing.T) {
+	if _, err := Replay(nil); err == nil {
+		t.Errorf("expected empty log to be rejected")
+	}
+	if _, err := Replay([]GameEvent{TableTrumpAnsweredEvent{Player: Player1}}); err == nil {
+		t.Errorf("expected log without GameStarted event to be rejected")
+	}
+}
@@ -1,6 +1,10 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
 
 // NOT thread-safe
 type BeloteGame struct {
@@ -9,11 +13,24 @@ type BeloteGame struct {
 	startingPlayer PlayerId
 	targetScore    int
 
-	currentHand *Hand
-	handNumber  int
+	currentHand   *Hand
+	handNumber    int
+	redeals       int
+	hangingPoints int
+	scoreSheet    []HandResult
+	acknowledged  map[PlayerId]bool
+	dealerFactory DealerFactory
+	rules         RuleSet
+
+	// deck is the deck the current hand was dealt from. While the game waits
+	// for a cut, it is the deck collected for the next hand.
+	deck []Card
+
+	events []GameEvent
 }
 
 const (
+	// NUM_PLAYERS is the most players a table seats, see Table.
 	NUM_PLAYERS            = 4
 	NUM_CARDS_PER_PLAYER   = 8
 	NUM_CARDS_BEFORE_TRUMP = 5
@@ -23,9 +40,13 @@ const (
 type GameState string
 
 const (
-	GameReady      GameState = "Ready"
-	GameInProgress GameState = "InProgress"
-	GameFinished   GameState = "Finished"
+	GameReady       GameState = "Ready"
+	GameInProgress  GameState = "InProgress"
+	GameHandSummary GameState = "HandSummary"
+	GameFinished    GameState = "Finished"
+
+	// GameCutting waits for the deck of the next hand to be cut, see Cut.
+	GameCutting GameState = "Cutting"
 )
 
 type PlayerId int
@@ -44,8 +65,12 @@ const (
 	NoTeamId TeamId = iota
 	Team1
 	Team2
+	// Team3 is Player3 playing alone at a three-player table.
+	Team3
 )
 
+// GetTeam returns the partnership of p at a four-player table. Table.Team
+// covers every table.
 func (p PlayerId) GetTeam() TeamId {
 	if p == Player1 || p == Player3 {
 		return Team1
@@ -53,6 +78,15 @@ func (p PlayerId) GetTeam() TeamId {
 	return Team2
 }
 
+// GetOpposingTeam returns the other partnership of a four-player table.
+func (t TeamId) GetOpposingTeam() TeamId {
+	if t == Team1 {
+		return Team2
+	}
+	return Team1
+}
+
+// GetTeammateId returns the partner of p at a four-player table.
 func (p PlayerId) GetTeammateId() PlayerId {
 	if p == Player1 {
 		return Player3
@@ -64,45 +98,59 @@ func (p PlayerId) GetTeammateId() PlayerId {
 	return Player2
 }
 
+// GetNextPlayerId returns the player after p at a four-player table.
 func (p PlayerId) GetNextPlayerId() PlayerId {
 	return (p-1+1)%4 + 1
 }
 
+// GetPreviousPlayerId returns the player before p at a four-player table.
 func (p PlayerId) GetPreviousPlayerId() PlayerId {
 	return (p-1+3)%4 + 1
 }
 
-func NewBeloteGame() BeloteGame {
-	scores := make(map[TeamId]int)
-	scores[Team1] = 0
-	scores[Team2] = 0
+func NewBeloteGame(rules RuleSet) BeloteGame {
+	return NewBeloteGameWithDealer(rules, NewRandomDealerFactory())
+}
 
-	// TODO: hand factory?
+// NewBeloteGameWithDealer creates a game whose hands are dealt by dealers
+// obtained from dealerFactory, one per hand.
+func NewBeloteGameWithDealer(rules RuleSet, dealerFactory DealerFactory) BeloteGame {
 	return BeloteGame{
 		state:          GameReady,
-		scores:         scores,
+		scores:         rules.Table().newTeamPoints(),
 		startingPlayer: Player1,
-		targetScore:    TARGET_SCORE,
+		targetScore:    rules.TargetScore,
 		currentHand:    nil,
 		handNumber:     0,
+		acknowledged:   map[PlayerId]bool{},
+		dealerFactory:  dealerFactory,
+		rules:          rules.clone(),
 	}
 }
 
 func (gm *BeloteGame) Start() {
 	gm.state = GameInProgress
+	gm.recordEvent(GameStartedEvent{StartingPlayer: gm.startingPlayer, Rules: gm.rules.clone()})
 	gm.setupHand()
 }
 
-func (gm *BeloteGame) PlayCard(player PlayerId, card Card, skipDeclarations bool) error {
+func (gm *BeloteGame) PlayCard(player PlayerId, card Card, announcement Announcement) error {
 	if gm.state != GameInProgress {
 		return fmt.Errorf("game is not in progress")
 	}
 
-	err := gm.currentHand.PlayCard(player, card, skipDeclarations)
+	completedTricks := len(gm.currentHand.CompletedTricks)
+
+	announced, err := gm.currentHand.playCard(player, card, announcement)
 	if err != nil {
 		return err
 	}
 
+	gm.recordEvent(CardPlayedEvent{Player: player, Card: card, Announcement: announced})
+	if len(gm.currentHand.CompletedTricks) > completedTricks {
+		gm.recordTrickCompleted()
+	}
+
 	if gm.currentHand.State == HandFinished {
 		gm.handleHandEnd()
 	}
@@ -115,7 +163,12 @@ func (gm *BeloteGame) AcceptTableTrump(player PlayerId, accept bool) error {
 		return fmt.Errorf("game is not in progress")
 	}
 
-	return gm.currentHand.AcceptTableTrump(player, accept)
+	if err := gm.currentHand.AcceptTableTrump(player, accept); err != nil {
+		return err
+	}
+
+	gm.recordEvent(TableTrumpAnsweredEvent{Player: player, Accepted: accept})
+	return nil
 }
 
 func (gm *BeloteGame) SelectTrump(player PlayerId, suit *Suit) error {
@@ -123,7 +176,202 @@ func (gm *BeloteGame) SelectTrump(player PlayerId, suit *Suit) error {
 		return fmt.Errorf("game is not in progress")
 	}
 
-	return gm.currentHand.SelectTrump(player, suit)
+	if err := gm.currentHand.SelectTrump(player, suit); err != nil {
+		return err
+	}
+
+	var selected *Suit
+	if suit != nil {
+		s := *suit
+		selected = &s
+	}
+	gm.recordEvent(TrumpSelectedEvent{Player: player, Suit: selected})
+	gm.redealIfAllPassed()
+	return nil
+}
+
+// Bid places bid for player in the Coinche auction, or passes when bid is
+// nil.
+func (gm *BeloteGame) Bid(player PlayerId, bid *Bid) error {
+	if gm.state != GameInProgress {
+		return fmt.Errorf("game is not in progress")
+	}
+
+	if err := gm.currentHand.Bid(player, bid); err != nil {
+		return err
+	}
+
+	var placed *Bid
+	if bid != nil {
+		b := *gm.currentHand.lastBid()
+		placed = &b
+	}
+	gm.recordEvent(BidPlacedEvent{Player: player, Bid: placed})
+	gm.redealIfAllPassed()
+	return nil
+}
+
+// redealIfAllPassed deals the hand again when every player passed on it.
+func (gm *BeloteGame) redealIfAllPassed() {
+	if gm.currentHand.State == HandRedeal {
+		gm.redeals++
+		gm.recordEvent(HandRedealtEvent{HandNumber: gm.handNumber, StartingPlayer: gm.currentHand.StartingPlayer})
+		gm.setupHand()
+	}
+}
+
+// SelectContract takes the hand in the free trump selection with contract.
+// A suit contract is the same as naming its suit with SelectTrump.
+func (gm *BeloteGame) SelectContract(player PlayerId, contract Contract) error {
+	if suit, ok := contract.TrumpSuit(); ok {
+		return gm.SelectTrump(player, &suit)
+	}
+	if gm.state != GameInProgress {
+		return fmt.Errorf("game is not in progress")
+	}
+
+	if err := gm.currentHand.SelectContract(player, contract); err != nil {
+		return err
+	}
+
+	gm.recordEvent(TrumpSelectedEvent{Player: player, Contract: &contract})
+	return nil
+}
+
+func (gm *BeloteGame) CallContra(player PlayerId, call bool) error {
+	if gm.state != GameInProgress {
+		return fmt.Errorf("game is not in progress")
+	}
+
+	if err := gm.currentHand.CallContra(player, call); err != nil {
+		return err
+	}
+
+	gm.recordEvent(ContraAnsweredEvent{Player: player, Called: call})
+	return nil
+}
+
+func (gm *BeloteGame) CallRecontra(player PlayerId, call bool) error {
+	if gm.state != GameInProgress {
+		return fmt.Errorf("game is not in progress")
+	}
+
+	if err := gm.currentHand.CallRecontra(player, call); err != nil {
+		return err
+	}
+
+	gm.recordEvent(RecontraAnsweredEvent{Player: player, Called: call})
+	return nil
+}
+
+// Cut cuts the deck of the next hand, lifting position cards from its top
+// and putting them under the rest, then deals the hand. Only the player
+// before the dealer cuts, see GetCuttingPlayer.
+func (gm *BeloteGame) Cut(player PlayerId, position int) error {
+	if gm.state != GameCutting {
+		return fmt.Errorf("deck is not waiting for a cut")
+	}
+
+	if player != gm.GetCuttingPlayer() {
+		return fmt.Errorf("not player's turn")
+	}
+
+	if position < MIN_CUT || position > len(gm.deck)-MIN_CUT {
+		return ErrInvalidCut
+	}
+
+	gm.recordEvent(DeckCutEvent{Player: player, Position: position})
+	gm.state = GameInProgress
+	gm.dealHand(cutDeck(gm.deck, position))
+	return nil
+}
+
+// Continue acknowledges the summary of the finished hand. The next hand is
+// dealt once every player has acknowledged it.
+func (gm *BeloteGame) Continue(player PlayerId) error {
+	if gm.state != GameHandSummary {
+		return fmt.Errorf("hand summary is not in progress")
+	}
+
+	if !slices.Contains(gm.rules.Table().PlayerIds(), player) {
+		return fmt.Errorf("player %d is not at the table", player)
+	}
+
+	if gm.acknowledged[player] {
+		return fmt.Errorf("player has already acknowledged the hand summary")
+	}
+
+	gm.acknowledged[player] = true
+	gm.recordEvent(HandSummaryAcknowledgedEvent{Player: player})
+
+	if len(gm.acknowledged) == gm.rules.Table().Players() {
+		gm.recordEvent(HandSummaryEndedEvent{Forced: false})
+		gm.refreshHand()
+	}
+
+	return nil
+}
+
+// EndHandSummary deals the next hand without waiting for every player to
+// acknowledge the summary, e.g. once a timeout expires.
+func (gm *BeloteGame) EndHandSummary() error {
+	if gm.state != GameHandSummary {
+		return fmt.Errorf("hand summary is not in progress")
+	}
+
+	gm.recordEvent(HandSummaryEndedEvent{Forced: true})
+	gm.refreshHand()
+	return nil
+}
+
+// PlayAction applies an action returned by LegalActions. Cards are played
+// with the best announcement the player can make.
+func (gm *BeloteGame) PlayAction(player PlayerId, action Action) error {
+	switch action.Type {
+	case AcceptTableTrumpAction:
+		return gm.AcceptTableTrump(player, action.Accept)
+	case SelectTrumpAction:
+		return gm.SelectTrump(player, action.Suit)
+	case SelectContractAction:
+		if action.Contract == nil {
+			return fmt.Errorf("no contract selected")
+		}
+		return gm.SelectContract(player, *action.Contract)
+	case ContraAction:
+		return gm.CallContra(player, action.Accept)
+	case RecontraAction:
+		return gm.CallRecontra(player, action.Accept)
+	case BidAction:
+		return gm.Bid(player, action.Bid)
+	case PlayCardAction:
+		return gm.PlayCard(player, action.Card, gm.currentHand.BestAnnouncement(player, action.Card))
+	case ContinueAction:
+		return gm.Continue(player)
+	case CutAction:
+		return gm.Cut(player, action.Cut)
+	}
+	return fmt.Errorf("unknown action type: %s", action.Type)
+}
+
+// LegalActions returns every action player may take right now.
+func (gm *BeloteGame) LegalActions(player PlayerId) []Action {
+	switch gm.state {
+	case GameInProgress:
+		return gm.currentHand.LegalActions(player)
+	case GameHandSummary:
+		if !gm.acknowledged[player] {
+			return []Action{{Type: ContinueAction}}
+		}
+	case GameCutting:
+		if player == gm.GetCuttingPlayer() {
+			var actions []Action
+			for position := MIN_CUT; position <= len(gm.deck)-MIN_CUT; position++ {
+				actions = append(actions, Action{Type: CutAction, Cut: position})
+			}
+			return actions
+		}
+	}
+	return nil
 }
 
 // TODO: return a copy?
@@ -136,36 +384,158 @@ func (gm *BeloteGame) GetHand() *Hand {
 	return gm.currentHand
 }
 
+func (gm *BeloteGame) GetHandNumber() int {
+	return gm.handNumber
+}
+
+// GetRedeals returns how many times the current hand was dealt again because
+// every player passed on trump, or in the auction.
+func (gm *BeloteGame) GetRedeals() int {
+	return gm.redeals
+}
+
+// GetAcknowledged returns the players who acknowledged the hand summary.
+func (gm *BeloteGame) GetAcknowledged() map[PlayerId]bool {
+	return gm.acknowledged
+}
+
 func (gm *BeloteGame) GetScores() map[TeamId]int {
 	return gm.scores
 }
 
+// GetScoreSheet returns the results of every finished hand, in order.
+func (gm *BeloteGame) GetScoreSheet() []HandResult {
+	return slices.Clone(gm.scoreSheet)
+}
+
+// GetHangingPoints returns the points of tied hands waiting to be credited to
+// the winner of the next hand.
+func (gm *BeloteGame) GetHangingPoints() int {
+	return gm.hangingPoints
+}
+
+func (gm *BeloteGame) GetRules() RuleSet {
+	return gm.rules.clone()
+}
+
+// GetEvents returns the ordered log of everything that happened in the game.
+func (gm *BeloteGame) GetEvents() []GameEvent {
+	return slices.Clone(gm.events)
+}
+
+// GetCuttingPlayer returns the player who cuts the deck of the next hand:
+// the player before its dealer, who sits before its starting player.
+func (gm *BeloteGame) GetCuttingPlayer() PlayerId {
+	table := gm.rules.Table()
+	dealer := table.Previous(gm.handStartingPlayer())
+	return table.Previous(dealer)
+}
+
+// setupHand deals the next hand from a fresh deck. With realistic dealing
+// the cards of the last hand are collected instead, and the hand is dealt
+// once they are cut. Only the first deck is shuffled.
 func (gm *BeloteGame) setupHand() {
-	gm.currentHand = NewHand(calculateHandStartingPlayer(gm.startingPlayer, gm.handNumber), NewRandomDealer())
+	if !gm.rules.RealisticDealing {
+		gm.dealHand(drawDeck(gm.dealerFactory()))
+		return
+	}
+
+	if gm.currentHand == nil {
+		gm.deck = drawDeck(gm.dealerFactory())
+		gm.recordEvent(DeckShuffledEvent{Deck: gm.deck})
+	} else {
+		gm.deck = collectCards(gm.currentHand, gm.deck)
+	}
+	gm.state = GameCutting
 }
 
-func calculateHandStartingPlayer(startingPlayer PlayerId, handNumber int) PlayerId {
-	return Player1 + (startingPlayer-Player1+PlayerId(handNumber))%NUM_PLAYERS
+func (gm *BeloteGame) dealHand(deck []Card) {
+	startingPlayer := gm.handStartingPlayer()
+	gm.deck = deck
+
+	gm.recordEvent(HandDealtEvent{
+		HandNumber:     gm.handNumber,
+		StartingPlayer: startingPlayer,
+		Deck:           deck,
+	})
+	gm.currentHand = NewHand(startingPlayer, NewFixedDealer(deck), gm.rules)
+}
+
+func (gm *BeloteGame) handStartingPlayer() PlayerId {
+	return calculateHandStartingPlayer(gm.startingPlayer, gm.handNumber, gm.rules.Table())
+}
+
+// drawDeck takes every card out of dealer so that the exact deal can be
+// recorded before the hand starts.
+func drawDeck(dealer Dealer) []Card {
+	deck := make([]Card, 0, MAX_DECK_SIZE)
+	for len(deck) < MAX_DECK_SIZE {
+		card, err := dealer.DealCard()
+		if err != nil {
+			break
+		}
+		deck = append(deck, card)
+	}
+	return deck
+}
+
+func calculateHandStartingPlayer(startingPlayer PlayerId, handNumber int, table Table) PlayerId {
+	return Player1 + (startingPlayer-Player1+PlayerId(handNumber))%PlayerId(table.Players())
 }
 
 func (gm *BeloteGame) handleHandEnd() {
-	gm.scores[Team1] += gm.currentHand.Totals[Team1]
-	gm.scores[Team2] += gm.currentHand.Totals[Team2]
+	result := newHandResult(gm.handNumber, gm.currentHand)
+
+	if winner := gm.currentHand.getWinner(); winner != NoTeamId {
+		result.Credited[winner] += gm.hangingPoints
+		result.CarriedPoints = gm.hangingPoints
+		gm.hangingPoints = 0
+	}
+	gm.hangingPoints += result.HungPoints
+
+	for _, team := range gm.rules.Table().Teams() {
+		gm.scores[team] += result.Credited[team]
+	}
+	result.Scores = maps.Clone(gm.scores)
+
+	gm.scoreSheet = append(gm.scoreSheet, result)
+	gm.recordEvent(HandCompletedEvent{Result: result})
 
 	if gm.checkEndCondition() {
 		gm.state = GameFinished
-		gm.currentHand = nil
 		return
 	}
 
-	gm.refreshHand()
+	gm.state = GameHandSummary
 }
 
 func (gm *BeloteGame) refreshHand() {
+	gm.state = GameInProgress
+	gm.acknowledged = map[PlayerId]bool{}
 	gm.handNumber++
+	gm.redeals = 0
 	gm.setupHand()
 }
 
+func (gm *BeloteGame) recordEvent(event GameEvent) {
+	gm.events = append(gm.events, event)
+}
+
+func (gm *BeloteGame) recordTrickCompleted() {
+	trick := gm.currentHand.CompletedTricks[len(gm.currentHand.CompletedTricks)-1]
+	result, err := trick.GetTrickResult()
+	if err != nil {
+		panic(err)
+	}
+
+	gm.recordEvent(TrickCompletedEvent{Winner: result.WinnerPlayer, Points: result.Points})
+}
+
 func (gm *BeloteGame) checkEndCondition() bool {
-	return gm.scores[Team1] >= gm.targetScore || gm.scores[Team2] >= gm.targetScore
+	for _, score := range gm.scores {
+		if score >= gm.targetScore {
+			return true
+		}
+	}
+	return false
 }
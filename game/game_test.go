@@ -0,0 +1,529 @@
+package game
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// playFirstValidCard plays the first card, in deck order, that the rules
+// accept for the player whose turn it is.
+func playFirstValidCard(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	player, err := gm.GetHand().GetCurrentTurn()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, card := range NewDeck() {
+		if !gm.GetHand().GetPlayerCards(player).Contains(card) {
+			continue
+		}
+		if err := gm.PlayCard(player, card, Announcement{}); err == nil {
+			return
+		}
+	}
+	t.Fatalf("player %d has no playable card", player)
+}
+
+// passDoubling lets every player pass in the contra and recontra windows.
+func passDoubling(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	for {
+		hand := gm.GetHand()
+		player, _ := hand.GetCurrentTurn()
+		switch hand.GetState() {
+		case ContraSelection:
+			if err := gm.CallContra(player, false); err != nil {
+				t.Fatal(err)
+			}
+		case RecontraSelection:
+			if err := gm.CallRecontra(player, false); err != nil {
+				t.Fatal(err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// continueToNextHand lets every player acknowledge the hand summary.
+func continueToNextHand(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	for _, player := range gm.GetRules().Table().PlayerIds() {
+		if err := gm.Continue(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// playGame plays a game to completion: the first player accepts every table
+// trump and then cards are played in deck order.
+func playGame(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	gm.Start()
+	for moves := 0; gm.GetState() != GameFinished; moves++ {
+		if moves > 10000 {
+			t.Fatal("game did not finish")
+		}
+		if gm.GetState() == GameHandSummary {
+			continueToNextHand(t, gm)
+			continue
+		}
+
+		hand := gm.GetHand()
+		switch hand.GetState() {
+		case TableTrumpSelection:
+			player, _ := hand.GetCurrentTurn()
+			if err := gm.AcceptTableTrump(player, true); err != nil {
+				t.Fatal(err)
+			}
+		case HandInProgress:
+			playFirstValidCard(t, gm)
+		default:
+			t.Fatalf("unexpected hand state %s", hand.GetState())
+		}
+	}
+}
+
+func TestTableTrumpAcceptedWithFixedDeal(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+
+	hand := gm.GetHand()
+	if hand.GetTableTrump() != (Card{Suit: Spades, Rank: Seven}) {
+		t.Fatalf("unexpected table trump %v", hand.GetTableTrump())
+	}
+
+	if err := gm.AcceptTableTrump(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if hand.GetTrump() != TrumpContract(Spades) || hand.GetState() != HandInProgress {
+		t.Fatalf("expected Spades hand in progress, got %s %s", hand.GetTrump(), hand.GetState())
+	}
+
+	for _, card := range NewDeck()[:NUM_CARDS_BEFORE_TRUMP+1] {
+		if !hand.GetPlayerCards(Player1).Contains(card) {
+			t.Errorf("expected Player1 to hold %v", card)
+		}
+	}
+	for player := Player1; player <= Player4; player++ {
+		if hand.GetPlayerCards(player).Len() != NUM_CARDS_PER_PLAYER {
+			t.Errorf("player %d holds %d cards", player, hand.GetPlayerCards(player).Len())
+		}
+	}
+}
+
+func TestTableJackGoesToLastPlayerWithFixedDeal(t *testing.T) {
+	deck := NewDeck()
+	jack := Card{Suit: Hearts, Rank: Jack}
+	for i, card := range deck {
+		if card == jack {
+			deck[0], deck[i] = deck[i], deck[0]
+		}
+	}
+
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(deck))
+	gm.Start()
+
+	hand := gm.GetHand()
+	if hand.GetState() != HandInProgress || hand.GetTrump() != TrumpContract(Hearts) {
+		t.Fatalf("expected Hearts hand in progress, got %s %s", hand.GetTrump(), hand.GetState())
+	}
+	if !hand.GetPlayerCards(Player4).Contains(jack) {
+		t.Errorf("expected Player4 to receive the table jack")
+	}
+}
+
+func TestSeededGamesAreReproducible(t *testing.T) {
+	first := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(2024))
+	second := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(2024))
+
+	playGame(t, &first)
+	playGame(t, &second)
+
+	if first.GetState() != GameFinished {
+		t.Fatalf("expected game to be finished, got %s", first.GetState())
+	}
+	if !maps.Equal(first.GetScores(), second.GetScores()) {
+		t.Errorf("expected identical scores, got %v and %v", first.GetScores(), second.GetScores())
+	}
+	if first.GetScores()[Team1] < TARGET_SCORE && first.GetScores()[Team2] < TARGET_SCORE {
+		t.Errorf("expected a team to reach the target score, got %v", first.GetScores())
+	}
+}
+
+func TestHungPointsGoToNextHandWinner(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(1))
+	gm.Start()
+
+	gm.currentHand = &Hand{
+		State:           HandFinished,
+		Totals:          map[TeamId]int{Team1: 0, Team2: 81},
+		ContractOutcome: ContractHung,
+		HungPoints:      81,
+		Multiplier:      1,
+	}
+	gm.handleHandEnd()
+
+	if gm.GetScores()[Team2] != 81 || gm.GetHangingPoints() != 81 {
+		t.Fatalf("expected 81 points to hang, got scores %v and %d hanging", gm.GetScores(), gm.GetHangingPoints())
+	}
+
+	gm.currentHand = &Hand{
+		State:           HandFinished,
+		Totals:          map[TeamId]int{Team1: 100, Team2: 62},
+		ContractOutcome: ContractMade,
+		Multiplier:      1,
+	}
+	gm.handleHandEnd()
+
+	expected := map[TeamId]int{Team1: 181, Team2: 143}
+	if !maps.Equal(gm.GetScores(), expected) || gm.GetHangingPoints() != 0 {
+		t.Errorf("expected scores %v, got %v and %d hanging", expected, gm.GetScores(), gm.GetHangingPoints())
+	}
+
+	sheet := gm.GetScoreSheet()
+	if sheet[0].HungPoints != 81 || sheet[1].CarriedPoints != 81 || sheet[1].Credited[Team1] != 181 {
+		t.Errorf("expected the score sheet to show the carried points, got %+v", sheet)
+	}
+}
+
+func TestHandEndAppliesMultiplier(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(1))
+	gm.Start()
+
+	gm.currentHand = &Hand{
+		State:           HandFinished,
+		Totals:          map[TeamId]int{Team1: 0, Team2: 162},
+		ContractOutcome: ContractInside,
+		Multiplier:      2,
+	}
+	gm.handleHandEnd()
+
+	if gm.GetScores()[Team2] != 324 {
+		t.Errorf("expected a doubled score of 324, got %v", gm.GetScores())
+	}
+}
+
+func TestScoreSheetBreaksDownScores(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(31))
+	playGame(t, &gm)
+
+	sheet := gm.GetScoreSheet()
+	if len(sheet) == 0 {
+		t.Fatal("expected a score sheet")
+	}
+
+	previous := map[TeamId]int{Team1: 0, Team2: 0}
+	for i, result := range sheet {
+		if result.HandNumber != i {
+			t.Errorf("expected hand number %d, got %d", i, result.HandNumber)
+		}
+
+		for _, team := range []TeamId{Team1, Team2} {
+			if result.Scores[team] != previous[team]+result.Credited[team] {
+				t.Errorf("hand %d: team %d credited %d but score went from %d to %d",
+					i, team, result.Credited[team], previous[team], result.Scores[team])
+			}
+
+			if result.Outcome != ContractMade {
+				continue
+			}
+			expected := result.TrickPoints[team] + result.DeclarationPoints[team] + result.BelotePoints[team]
+			for _, bonus := range result.Bonuses {
+				if bonus.Team != team {
+					continue
+				}
+				if bonus.Type == CapotBonus {
+					expected += bonus.Points - result.TrickPoints[team]
+				} else {
+					expected += bonus.Points
+				}
+			}
+			if result.Totals[team] != expected {
+				t.Errorf("hand %d: team %d totals %d do not match breakdown %d", i, team, result.Totals[team], expected)
+			}
+		}
+		previous = result.Scores
+	}
+
+	if !maps.Equal(previous, gm.GetScores()) {
+		t.Errorf("expected the last result to hold final scores %v, got %v", gm.GetScores(), previous)
+	}
+}
+
+// playHand plays the current hand until it is finished.
+func playHand(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	for gm.GetState() == GameInProgress {
+		hand := gm.GetHand()
+		player, _ := hand.GetCurrentTurn()
+		switch hand.GetState() {
+		case TableTrumpSelection:
+			if err := gm.AcceptTableTrump(player, true); err != nil {
+				t.Fatal(err)
+			}
+		case ContraSelection, RecontraSelection:
+			passDoubling(t, gm)
+		case HandInProgress:
+			playFirstValidCard(t, gm)
+		}
+	}
+}
+
+func TestHandSummaryWaitsForEveryPlayer(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(4))
+	gm.Start()
+	playHand(t, &gm)
+
+	if gm.GetState() != GameHandSummary {
+		t.Fatalf("expected hand summary, got %s", gm.GetState())
+	}
+	hand := gm.GetHand()
+	if hand.GetState() != HandFinished {
+		t.Errorf("expected the finished hand to be kept, got %s", hand.GetState())
+	}
+	for player := Player1; player <= Player4; player++ {
+		if len(hand.InitialCards[player]) != NUM_CARDS_PER_PLAYER {
+			t.Errorf("expected player %d initial cards to be kept, got %v", player, hand.InitialCards[player])
+		}
+	}
+
+	for player := Player1; player <= Player3; player++ {
+		if actions := gm.LegalActions(player); len(actions) != 1 || actions[0].Type != ContinueAction {
+			t.Errorf("expected player %d to be able to continue, got %v", player, actions)
+		}
+		if err := gm.Continue(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := gm.Continue(Player1); err == nil {
+		t.Errorf("expected a second acknowledgement to be rejected")
+	}
+	if len(gm.LegalActions(Player1)) != 0 {
+		t.Errorf("expected no actions after acknowledging")
+	}
+	if gm.GetState() != GameHandSummary || gm.GetHandNumber() != 0 {
+		t.Fatalf("expected summary to wait for Player4, got %s", gm.GetState())
+	}
+
+	if err := gm.Continue(Player4); err != nil {
+		t.Fatal(err)
+	}
+	if gm.GetState() != GameInProgress || gm.GetHandNumber() != 1 || gm.GetHand() == hand {
+		t.Errorf("expected the next hand to be dealt, got %s at hand %d", gm.GetState(), gm.GetHandNumber())
+	}
+}
+
+func TestHandSummaryRejectsPlayersNotAtTheTable(t *testing.T) {
+	rules, _ := GetRuleSetPreset(ThreePlayerRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(4))
+	gm.Start()
+	playHand(t, &gm)
+
+	for _, player := range []PlayerId{NoPlayerId, Player4, Player4 + 1} {
+		if err := gm.Continue(player); err == nil {
+			t.Errorf("expected player %d to be rejected", player)
+		}
+	}
+	for _, player := range []PlayerId{Player1, Player2} {
+		if err := gm.Continue(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gm.GetState() != GameHandSummary || len(gm.GetAcknowledged()) != 2 {
+		t.Errorf("expected the summary to wait for player 3, got %s with %v", gm.GetState(), gm.GetAcknowledged())
+	}
+}
+
+func TestEndHandSummaryDealsNextHand(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(4))
+	gm.Start()
+	playHand(t, &gm)
+
+	if err := gm.Continue(Player2); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.EndHandSummary(); err != nil {
+		t.Fatal(err)
+	}
+	if gm.GetState() != GameInProgress || gm.GetHandNumber() != 1 {
+		t.Fatalf("expected the next hand to be dealt, got %s at hand %d", gm.GetState(), gm.GetHandNumber())
+	}
+	if err := gm.EndHandSummary(); err == nil {
+		t.Errorf("expected no summary to end while a hand is in progress")
+	}
+
+	replayed, err := Replay(gm.GetEvents())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.GetHandNumber() != 1 || replayed.GetState() != GameInProgress {
+		t.Errorf("expected the forced summary end to be replayed")
+	}
+}
+
+func TestRealisticDealingCutsTheCollectedCards(t *testing.T) {
+	rules, _ := GetRuleSetPreset(RealisticRuleSetPreset)
+	rules.TableJackAutoAssigned = false
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+
+	if gm.GetState() != GameCutting || gm.GetHand() != nil {
+		t.Fatalf("expected the first deck to wait for a cut, got %s", gm.GetState())
+	}
+	if cutter := gm.GetCuttingPlayer(); cutter != Player3 {
+		t.Fatalf("expected the player before the dealer to cut, got %d", cutter)
+	}
+	if err := gm.Cut(Player4, 10); err == nil {
+		t.Errorf("expected a cut by the dealer to be rejected")
+	}
+	if err := gm.Cut(Player3, MAX_DECK_SIZE-2); !errors.Is(err, ErrInvalidCut) {
+		t.Errorf("expected %v, got %v", ErrInvalidCut, err)
+	}
+	if err := gm.PlayAction(Player3, Action{Type: CutAction, Cut: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	deck := cutDeck(NewDeck(), 10)
+	hand := gm.GetHand()
+	if hand.GetTableTrump() != deck[NUM_CARDS_BEFORE_TRUMP*NUM_PLAYERS] {
+		t.Errorf("expected the card after the packets to be turned, got %v", hand.GetTableTrump())
+	}
+	if !hand.GetPlayerCards(Player1).Contains(deck[0]) || !hand.GetPlayerCards(Player1).Contains(deck[12]) {
+		t.Errorf("expected player 1 to be dealt three cards then two, got %v", hand.GetPlayerCards(Player1).Cards())
+	}
+
+	playHand(t, &gm)
+	tricks := hand.CompletedTricks
+	for _, player := range rules.Table().PlayerIds() {
+		if err := gm.Continue(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gm.GetState() != GameCutting || gm.GetCuttingPlayer() != Player4 {
+		t.Fatalf("expected player 4 to cut the next deck, got %s", gm.GetState())
+	}
+	if collected := collectCards(hand, deck); !slices.Equal(gm.deck, collected) {
+		t.Errorf("expected the tricks to be collected for the next deck")
+	}
+	if !slices.Equal(gm.deck[:NUM_PLAYERS], []Card{tricks[0].Cards[Player1], tricks[0].Cards[Player2], tricks[0].Cards[Player3], tricks[0].Cards[Player4]}) {
+		t.Errorf("expected the first trick on top of the next deck, got %v", gm.deck[:NUM_PLAYERS])
+	}
+
+	replayed, err := Replay(gm.GetEvents())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(replayed.deck, gm.deck) {
+		t.Errorf("expected the collected deck to be replayed")
+	}
+}
+
+func TestRealisticRedealCollectsTheDealtCards(t *testing.T) {
+	rules, _ := GetRuleSetPreset(RealisticRuleSetPreset)
+	rules.TableJackAutoAssigned = false
+	rules.RedealWhenAllPass = true
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	if err := gm.Cut(Player3, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	hand := gm.GetHand()
+	deck := gm.deck
+	for _, player := range rules.Table().PlayerIds() {
+		if err := gm.AcceptTableTrump(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, player := range rules.Table().PlayerIds() {
+		if err := gm.SelectTrump(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gm.GetState() != GameCutting || gm.GetRedeals() != 1 {
+		t.Fatalf("expected the redeal to wait for a cut, got %s", gm.GetState())
+	}
+
+	var expected []Card
+	for _, player := range rules.Table().PlayerIds() {
+		for _, card := range deck {
+			if hand.GetPlayerCards(player).Contains(card) {
+				expected = append(expected, card)
+			}
+		}
+	}
+	if !slices.Equal(gm.deck[:len(expected)], expected) {
+		t.Errorf("expected the dealt cards to be collected in seat order, got %v", gm.deck[:len(expected)])
+	}
+
+	if err := gm.Cut(Player3, 10); err != nil {
+		t.Fatal(err)
+	}
+	if slices.Equal(gm.deck, deck) {
+		t.Fatalf("expected the redeal to be dealt from a new deck order")
+	}
+	redealt := gm.GetHand()
+	same := 0
+	for _, player := range rules.Table().PlayerIds() {
+		if redealt.GetPlayerCards(player) == hand.GetPlayerCards(player) {
+			same++
+		}
+	}
+	if same > 0 {
+		t.Errorf("expected every player to be dealt different cards, %d got the same", same)
+	}
+}
+
+func TestPlayerViewShowsOnlyOwnCards(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(4))
+	gm.Start()
+
+	for player := Player1; player <= Player4; player++ {
+		view := gm.GetPlayerView(player)
+		if len(view.Cards) != gm.GetHand().GetPlayerCards(player).Len() {
+			t.Errorf("expected player %d to see their %d cards, got %d", player, gm.GetHand().GetPlayerCards(player).Len(), len(view.Cards))
+		}
+		for _, card := range view.Cards {
+			if !gm.GetHand().GetPlayerCards(player).Contains(card) {
+				t.Errorf("player %d sees %v, which is not theirs", player, card)
+			}
+		}
+
+		turn, _ := gm.GetHand().GetCurrentTurn()
+		if (player == turn) != (len(view.LegalActions) > 0) {
+			t.Errorf("expected only player %d to have legal actions, player %d has %d", turn, player, len(view.LegalActions))
+		}
+	}
+}
+
+func TestPlayActionAppliesLegalActions(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(8))
+	gm.Start()
+
+	for moves := 0; gm.GetState() != GameFinished; moves++ {
+		if moves > 10000 {
+			t.Fatal("game did not finish")
+		}
+		for player := Player1; player <= Player4; player++ {
+			actions := gm.LegalActions(player)
+			if len(actions) == 0 {
+				continue
+			}
+			if err := gm.PlayAction(player, actions[len(actions)-1]); err != nil {
+				t.Fatalf("player %d: %v", player, err)
+			}
+			break
+		}
+	}
+}
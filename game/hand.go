@@ -7,12 +7,23 @@ import (
 )
 
 type Hand struct {
-	State          HandState
-	CurrentTrick   *Trick
-	PreviousTrick  *Trick
-	StartingPlayer PlayerId
-	Totals         map[TeamId]int
-	PlayerCards    map[PlayerId]map[Card]bool
+	State           HandState
+	CurrentTrick    *Trick
+	PreviousTrick   *Trick
+	CompletedTricks []*Trick
+	StartingPlayer  PlayerId
+	Totals          map[TeamId]int
+	PlayerCards     map[PlayerId]CardSet
+	InitialCards    map[PlayerId][]Card
+	// Talon counts the cards two players have left to draw after tricks.
+	Talon int
+
+	// Bids are the bids of an auction in order. Calls counts every call,
+	// passes and doubles included, and Passes the passes since the last
+	// other call.
+	Bids   []Bid
+	Calls  int
+	Passes int
 
 	TableTrumpCard            Card
 	TableTrumpSelectionStatus map[PlayerId]bool
@@ -20,84 +31,176 @@ type Hand struct {
 
 	PlayerDeclarations map[PlayerId][]Declaration
 	DeclarationWinner  *TeamId
+	// RevealedCards holds the cards of the winning declarations, shown to
+	// everyone once the first trick is complete.
+	RevealedCards map[PlayerId]CardSet
 
-	Trump Suit
+	TrickPoints       map[TeamId]int
+	DeclarationPoints map[TeamId]int
+	BelotePoints      map[TeamId]int
+	Bonuses           []HandBonus
+
+	Taker           PlayerId
+	TakerTeam       TeamId
+	ContractOutcome ContractOutcome
+	HungPoints      int
+
+	ContraSelectionStatus   map[PlayerId]bool
+	RecontraSelectionStatus map[PlayerId]bool
+	Multiplier              int
+
+	Trump Contract
 
 	dealer Dealer
+	rules  RuleSet
 }
 
 type Dealer interface {
 	DealCard() (Card, error)
 }
 
+type HandBonusType string
+
+const (
+	LastTrickBonus HandBonusType = "LastTrick"
+	CapotBonus     HandBonusType = "Capot"
+)
+
+// HandBonus is awarded on top of card points at the end of a hand. A capot
+// replaces the card points of the team that took every trick.
+type HandBonus struct {
+	Type   HandBonusType `json:"type"`
+	Team   TeamId        `json:"team"`
+	Points int           `json:"points"`
+}
+
+// ContractOutcome tells how the taking team fared once a hand is finished.
+type ContractOutcome string
+
+const (
+	ContractPending ContractOutcome = ""
+	ContractMade    ContractOutcome = "Made"
+	ContractInside  ContractOutcome = "Inside"
+	ContractHung    ContractOutcome = "Hung"
+)
+
 type HandState string
 
 const (
+	// Bidding is the auction of a Coinche or Bulgarian hand.
+	Bidding             HandState = "Bidding"
 	TableTrumpSelection HandState = "TableTrumpSelection"
 	FreeTrumpSelection  HandState = "FreeTrumpSelection"
+	ContraSelection     HandState = "ContraSelection"
+	RecontraSelection   HandState = "RecontraSelection"
 	HandInProgress      HandState = "HandInProgress"
 	HandFinished        HandState = "HandFinished"
+	// HandRedeal ends a hand in which every player passed on trump. The game
+	// deals a new one in its place.
+	HandRedeal HandState = "Redeal"
 )
 
-func NewHand(startingPlayer PlayerId, dealer Dealer) *Hand {
+func NewHand(startingPlayer PlayerId, dealer Dealer, rules RuleSet) *Hand {
+	table := rules.Table()
 	hand := &Hand{
 		State:                     TableTrumpSelection,
 		CurrentTrick:              nil,
 		PreviousTrick:             nil,
+		CompletedTricks:           nil,
 		StartingPlayer:            startingPlayer,
-		Totals:                    nil,
-		PlayerCards:               makePlayerCards(),
+		Totals:                    table.newTeamPoints(),
+		PlayerCards:               makePlayerCards(table),
+		InitialCards:              map[PlayerId][]Card{},
 		TableTrumpCard:            Card{},
+		Talon:                     table.talonSize(),
 		TableTrumpSelectionStatus: map[PlayerId]bool{},
 		FreeTrumpSelectionStatus:  map[PlayerId]bool{},
 		PlayerDeclarations:        map[PlayerId][]Declaration{},
 		DeclarationWinner:         nil,
-		Trump:                     Spades,
+		RevealedCards:             map[PlayerId]CardSet{},
+		TrickPoints:               table.newTeamPoints(),
+		DeclarationPoints:         table.newTeamPoints(),
+		BelotePoints:              table.newTeamPoints(),
+		Bonuses:                   nil,
+		Taker:                     NoPlayerId,
+		TakerTeam:                 NoTeamId,
+		ContractOutcome:           ContractPending,
+		HungPoints:                0,
+		ContraSelectionStatus:     map[PlayerId]bool{},
+		RecontraSelectionStatus:   map[PlayerId]bool{},
+		Multiplier:                1,
+		Trump:                     TrumpContract(Spades),
 		dealer:                    dealer,
+		rules:                     rules,
 	}
 
-	hand.Totals = map[TeamId]int{}
-	hand.Totals[Team1] = 0
-	hand.Totals[Team2] = 0
-
-	tableTrumpCard, err := dealer.DealCard()
-	if err != nil {
-		panic(err)
+	switch rules.Mode {
+	case CoincheMode:
+		hand.State = Bidding
+		if rules.dealsInPackets() {
+			hand.dealInitialCards()
+		}
+		hand.dealCards()
+		return hand
+	case BulgarianMode:
+		hand.State = Bidding
+		hand.dealInitialCards()
+		return hand
 	}
 
-	if tableTrumpCard.Rank == Jack {
-		hand.PlayerCards[hand.getLastPlayer()][tableTrumpCard] = true
-		hand.handleTrumpSelected(tableTrumpCard.Suit)
+	if rules.dealsInPackets() {
+		hand.dealInitialCards()
+		hand.turnTableTrumpCard()
 		return hand
 	}
 
-	hand.TableTrumpCard = tableTrumpCard
-	hand.dealInitialCards()
-
+	hand.turnTableTrumpCard()
+	if hand.State == TableTrumpSelection {
+		hand.dealInitialCards()
+	}
 	return hand
 }
 
-func (h *Hand) PlayCard(player PlayerId, card Card, skipDeclarations bool) error {
-	if h.State != HandInProgress {
-		return fmt.Errorf("hand is not in progress")
+// turnTableTrumpCard deals the table trump card. A Jack goes straight to the
+// last player when the rules say so, which deals the rest of the cards.
+func (h *Hand) turnTableTrumpCard() {
+	card := h.dealCard()
+	if h.rules.TableJackAutoAssigned && card.Rank == Jack {
+		h.addCard(h.getLastPlayer(), card)
+		h.handleTrumpSelected(h.getLastPlayer(), TrumpContract(card.Suit))
+		return
 	}
+	h.TableTrumpCard = card
+}
 
-	playerCards := slices.Collect(maps.Keys(h.PlayerCards[player]))
+// PlayCard plays card for player, announcing what announcement lists.
+func (h *Hand) PlayCard(player PlayerId, card Card, announcement Announcement) error {
+	_, err := h.playCard(player, card, announcement)
+	return err
+}
 
-	if err := h.CurrentTrick.PlayCard(player, card, h.PlayerCards[player]); err != nil {
-		return err
+// playCard plays card and returns the announcement as it was recorded.
+func (h *Hand) playCard(player PlayerId, card Card, announcement Announcement) (Announcement, error) {
+	if h.State != HandInProgress {
+		return Announcement{}, fmt.Errorf("hand is not in progress")
 	}
 
-	if !skipDeclarations {
-		if h.PreviousTrick == nil {
-			for _, d := range FindPreHandDeclarations(playerCards) {
-				h.PlayerDeclarations[player] = append(h.PlayerDeclarations[player], d)
-			}
-		}
+	declarations, err := h.checkAnnouncement(player, card, announcement)
+	if err != nil {
+		return Announcement{}, err
+	}
+	if err := h.CurrentTrick.PlayCard(player, card, h.PlayerCards[player]); err != nil {
+		return Announcement{}, err
+	}
+	h.PlayerCards[player] = h.PlayerCards[player].Without(card)
 
-		if card.Suit == h.Trump && (card.Rank == King || card.Rank == Queen) && HasBelote(playerCards, h.Trump) {
-			h.PlayerDeclarations[player] = append(h.PlayerDeclarations[player], Belote{})
-			h.Totals[player.GetTeam()] += Belote{}.Points()
+	for _, d := range declarations {
+		h.PlayerDeclarations[player] = append(h.PlayerDeclarations[player], d)
+	}
+	if announcement.Belote {
+		h.PlayerDeclarations[player] = append(h.PlayerDeclarations[player], Belote{})
+		if h.rules.BeloteCountsWithoutDeclarationWin || h.PreviousTrick != nil {
+			h.scoreBelote(player)
 		}
 	}
 
@@ -113,7 +216,7 @@ func (h *Hand) PlayCard(player PlayerId, card Card, skipDeclarations bool) error
 		h.handleTrickResult(trickResult)
 	}
 
-	return nil
+	return Announcement{Declarations: declarations, Belote: announcement.Belote}, nil
 }
 
 func (h *Hand) AcceptTableTrump(player PlayerId, accept bool) error {
@@ -126,13 +229,13 @@ func (h *Hand) AcceptTableTrump(player PlayerId, accept bool) error {
 	}
 
 	if accept {
-		h.PlayerCards[player][h.TableTrumpCard] = true
-		h.handleTrumpSelected(h.TableTrumpCard.Suit)
+		h.addCard(player, h.TableTrumpCard)
+		h.handleTrumpSelected(player, TrumpContract(h.TableTrumpCard.Suit))
 		return nil
 	}
 
 	h.TableTrumpSelectionStatus[player] = true
-	if len(h.TableTrumpSelectionStatus) == NUM_PLAYERS {
+	if len(h.TableTrumpSelectionStatus) == h.table().Players() {
 		h.State = FreeTrumpSelection
 	}
 
@@ -140,6 +243,22 @@ func (h *Hand) AcceptTableTrump(player PlayerId, accept bool) error {
 }
 
 func (h *Hand) SelectTrump(player PlayerId, suit *Suit) error {
+	var contract *Contract
+	if suit != nil {
+		c := TrumpContract(*suit)
+		contract = &c
+	}
+	return h.selectContract(player, contract)
+}
+
+// SelectContract takes the hand in the free trump selection with contract,
+// which may be a no-trumps or all-trumps contract when the rules allow it.
+func (h *Hand) SelectContract(player PlayerId, contract Contract) error {
+	return h.selectContract(player, &contract)
+}
+
+// selectContract answers the free trump selection. A nil contract is a pass.
+func (h *Hand) selectContract(player PlayerId, contract *Contract) error {
 	if h.State != FreeTrumpSelection {
 		return fmt.Errorf("free trump selection is not in progress")
 	}
@@ -148,24 +267,141 @@ func (h *Hand) SelectTrump(player PlayerId, suit *Suit) error {
 		return err
 	}
 
-	if player == h.getLastPlayer() && suit == nil {
+	if player == h.getLastPlayer() && contract == nil && !h.rules.RedealWhenAllPass {
 		return fmt.Errorf("final player must select a trump suit")
 	}
 
-	if suit == nil {
+	if contract == nil {
 		h.FreeTrumpSelectionStatus[player] = true
+		if len(h.FreeTrumpSelectionStatus) == h.table().Players() {
+			h.State = HandRedeal
+		}
 		return nil
 	}
 
-	if *suit == h.TableTrumpCard.Suit {
+	if !h.rules.allowsContract(*contract) {
+		return fmt.Errorf("contract %s is not allowed", *contract)
+	}
+	if *contract == TrumpContract(h.TableTrumpCard.Suit) {
 		return fmt.Errorf("trump suit cannot be the same as table trump suit")
 	}
 
-	h.PlayerCards[player][h.TableTrumpCard] = true
-	h.handleTrumpSelected(*suit)
+	h.addCard(player, h.TableTrumpCard)
+	h.handleTrumpSelected(player, *contract)
+	return nil
+}
+
+// CallContra answers the contra window opened once the trump is chosen: a
+// defender either doubles the hand or passes. Defenders answer in seat order.
+// During an auction, calling a contra is the coinche or the double.
+func (h *Hand) CallContra(player PlayerId, call bool) error {
+	if h.State == Bidding {
+		return h.double(player, call)
+	}
+	if h.State != ContraSelection {
+		return fmt.Errorf("contra selection is not in progress, current state: %s", h.State)
+	}
+
+	if err := h.checkIsDoublingTurnFor(player, false, h.ContraSelectionStatus); err != nil {
+		return err
+	}
+
+	h.ContraSelectionStatus[player] = true
+	if call {
+		h.Multiplier = 2
+		h.State = RecontraSelection
+		return nil
+	}
+
+	if _, err := h.getCurrentDoublingTurn(false, h.ContraSelectionStatus); err != nil {
+		h.State = HandInProgress
+	}
+
 	return nil
 }
 
+// CallRecontra lets the takers answer a contra by doubling the hand again.
+// During a Bulgarian auction, calling a recontra is the redouble.
+func (h *Hand) CallRecontra(player PlayerId, call bool) error {
+	if h.State == Bidding {
+		return h.redouble(player, call)
+	}
+	if h.State != RecontraSelection {
+		return fmt.Errorf("recontra selection is not in progress, current state: %s", h.State)
+	}
+
+	if err := h.checkIsDoublingTurnFor(player, true, h.RecontraSelectionStatus); err != nil {
+		return err
+	}
+
+	h.RecontraSelectionStatus[player] = true
+	if call {
+		h.Multiplier = 4
+		h.State = HandInProgress
+		return nil
+	}
+
+	if _, err := h.getCurrentDoublingTurn(true, h.RecontraSelectionStatus); err != nil {
+		h.State = HandInProgress
+	}
+
+	return nil
+}
+
+// Clone returns a deep copy of the hand that can be played on independently.
+// The clone shares the dealer and the rules of the hand, so only hands whose
+// cards are all dealt, talon included, should be cloned.
+func (h *Hand) Clone() *Hand {
+	clone := *h
+
+	tricks := make(map[*Trick]*Trick, len(h.CompletedTricks)+2)
+	cloneShared := func(trick *Trick) *Trick {
+		if trick == nil {
+			return nil
+		}
+		if _, ok := tricks[trick]; !ok {
+			tricks[trick] = trick.Clone()
+		}
+		return tricks[trick]
+	}
+
+	clone.CurrentTrick = cloneShared(h.CurrentTrick)
+	clone.PreviousTrick = cloneShared(h.PreviousTrick)
+	clone.CompletedTricks = make([]*Trick, 0, len(h.CompletedTricks))
+	for _, trick := range h.CompletedTricks {
+		clone.CompletedTricks = append(clone.CompletedTricks, cloneShared(trick))
+	}
+
+	clone.Totals = maps.Clone(h.Totals)
+	clone.PlayerCards = maps.Clone(h.PlayerCards)
+	clone.InitialCards = make(map[PlayerId][]Card, len(h.InitialCards))
+	for player, cards := range h.InitialCards {
+		clone.InitialCards[player] = slices.Clone(cards)
+	}
+
+	clone.Bids = slices.Clone(h.Bids)
+	clone.TableTrumpSelectionStatus = maps.Clone(h.TableTrumpSelectionStatus)
+	clone.FreeTrumpSelectionStatus = maps.Clone(h.FreeTrumpSelectionStatus)
+	clone.PlayerDeclarations = make(map[PlayerId][]Declaration, len(h.PlayerDeclarations))
+	for player, decls := range h.PlayerDeclarations {
+		clone.PlayerDeclarations[player] = slices.Clone(decls)
+	}
+	if h.DeclarationWinner != nil {
+		winner := *h.DeclarationWinner
+		clone.DeclarationWinner = &winner
+	}
+	clone.RevealedCards = maps.Clone(h.RevealedCards)
+
+	clone.TrickPoints = maps.Clone(h.TrickPoints)
+	clone.DeclarationPoints = maps.Clone(h.DeclarationPoints)
+	clone.BelotePoints = maps.Clone(h.BelotePoints)
+	clone.Bonuses = slices.Clone(h.Bonuses)
+	clone.ContraSelectionStatus = maps.Clone(h.ContraSelectionStatus)
+	clone.RecontraSelectionStatus = maps.Clone(h.RecontraSelectionStatus)
+
+	return &clone
+}
+
 func (h *Hand) GetTrick() *Trick {
 	if h.State == HandInProgress {
 		// TODO: copy?
@@ -174,9 +410,8 @@ func (h *Hand) GetTrick() *Trick {
 	return nil
 }
 
-// TODO: copy?
 // TODO: hide?
-func (h *Hand) GetPlayerCards(player PlayerId) map[Card]bool {
+func (h *Hand) GetPlayerCards(player PlayerId) CardSet {
 	return h.PlayerCards[player]
 }
 
@@ -188,7 +423,7 @@ func (h *Hand) GetState() HandState {
 	return h.State
 }
 
-func (h *Hand) GetTrump() Suit {
+func (h *Hand) GetTrump() Contract {
 	return h.Trump
 }
 
@@ -196,56 +431,298 @@ func (h *Hand) GetTableTrump() Card {
 	return h.TableTrumpCard
 }
 
+func (h *Hand) GetRules() RuleSet {
+	return h.rules.clone()
+}
+
 func (h *Hand) GetCurrentTurn() (PlayerId, error) {
 	switch h.State {
+	case Bidding:
+		return h.getCurrentBiddingTurn(), nil
 	case TableTrumpSelection:
 		return h.getCurrentTrumpSelectionTurn(h.TableTrumpSelectionStatus)
 	case FreeTrumpSelection:
 		return h.getCurrentTrumpSelectionTurn(h.FreeTrumpSelectionStatus)
+	case ContraSelection:
+		return h.getCurrentDoublingTurn(false, h.ContraSelectionStatus)
+	case RecontraSelection:
+		return h.getCurrentDoublingTurn(true, h.RecontraSelectionStatus)
 	case HandInProgress:
 		return h.CurrentTrick.GetCurrentTurn()
 	case HandFinished:
 		return Player1, fmt.Errorf("hand is finished")
+	case HandRedeal:
+		return Player1, fmt.Errorf("hand is being redealt")
 	default:
 		panic(fmt.Sprintf("unexpected game.HandState: %#v", h.State))
 	}
 }
 
+// LegalCards returns the cards player may play right now, sorted with
+// CompareCards. It is empty when it is not player's turn to play a card.
+func (h *Hand) LegalCards(player PlayerId) []Card {
+	if h.State != HandInProgress {
+		return nil
+	}
+
+	currentPlayer, err := h.CurrentTrick.GetCurrentTurn()
+	if err != nil || currentPlayer != player {
+		return nil
+	}
+
+	return h.CurrentTrick.GetLegalCards(h.PlayerCards[player])
+}
+
+// LegalActions returns every action player may take right now, in any state
+// of the hand. It is empty when it is not player's turn.
+func (h *Hand) LegalActions(player PlayerId) []Action {
+	currentPlayer, err := h.GetCurrentTurn()
+	if err != nil || currentPlayer != player {
+		return nil
+	}
+
+	var actions []Action
+	switch h.State {
+	case Bidding:
+		actions = h.biddingActions(player)
+	case TableTrumpSelection:
+		actions = append(actions,
+			Action{Type: AcceptTableTrumpAction, Accept: true},
+			Action{Type: AcceptTableTrumpAction, Accept: false},
+		)
+	case FreeTrumpSelection:
+		if player != h.getLastPlayer() || h.rules.RedealWhenAllPass {
+			actions = append(actions, Action{Type: SelectTrumpAction, Suit: nil})
+		}
+		for _, suit := range suits {
+			if suit != h.TableTrumpCard.Suit {
+				actions = append(actions, Action{Type: SelectTrumpAction, Suit: &suit})
+			}
+		}
+		for _, contract := range []Contract{NoTrumpsContract, AllTrumpsContract} {
+			if h.rules.allowsContract(contract) {
+				actions = append(actions, Action{Type: SelectContractAction, Contract: &contract})
+			}
+		}
+	case ContraSelection:
+		actions = append(actions,
+			Action{Type: ContraAction, Accept: true},
+			Action{Type: ContraAction, Accept: false},
+		)
+	case RecontraSelection:
+		actions = append(actions,
+			Action{Type: RecontraAction, Accept: true},
+			Action{Type: RecontraAction, Accept: false},
+		)
+	case HandInProgress:
+		for _, card := range h.LegalCards(player) {
+			actions = append(actions, Action{Type: PlayCardAction, Card: card})
+		}
+	}
+	return actions
+}
+
 func (h *Hand) dealInitialCards() {
-	for player := Player1; player <= Player4; player += 1 {
-		for len(h.PlayerCards[player]) < NUM_CARDS_BEFORE_TRUMP {
-			cardDealt, err := h.dealer.DealCard()
-			if err != nil {
-				panic(err)
+	if h.rules.dealsInPackets() {
+		h.dealPackets(h.rules.firstPackets()...)
+		return
+	}
+	for _, player := range h.table().PlayerIds() {
+		for h.PlayerCards[player].Len() < NUM_CARDS_BEFORE_TRUMP {
+			h.addCard(player, h.dealCard())
+		}
+	}
+}
+
+// dealPackets deals every player a packet of each size in turn, in playing
+// order from the starting player.
+func (h *Hand) dealPackets(sizes ...int) {
+	for _, size := range sizes {
+		for _, player := range h.table().PlayerIdsFrom(h.StartingPlayer) {
+			for range size {
+				h.addCard(player, h.dealCard())
 			}
-			h.PlayerCards[player][cardDealt] = true
 		}
 	}
 }
 
+func (h *Hand) dealCard() Card {
+	card, err := h.dealer.DealCard()
+	if err != nil {
+		panic(err)
+	}
+	return card
+}
+
 func (h *Hand) handleTrickResult(trickResult *TrickResult) {
-	h.Totals[trickResult.WinnerPlayer.GetTeam()] += trickResult.Points
+	winner := h.table().Team(trickResult.WinnerPlayer)
+	h.CompletedTricks = append(h.CompletedTricks, h.CurrentTrick)
+	h.Totals[winner] += trickResult.Points
+	h.TrickPoints[winner] += trickResult.Points
+	h.drawFromTalon(trickResult.WinnerPlayer)
 
 	if h.checkEndCondition() {
+		h.scoreBonuses(winner)
+		h.scoreContract()
 		h.State = HandFinished
 		return
 	}
 
 	h.PreviousTrick = h.CurrentTrick
-	h.CurrentTrick = NewTrick(trickResult.WinnerPlayer, h.Trump)
+	h.CurrentTrick = NewTrick(trickResult.WinnerPlayer, h.Trump, h.table())
+}
+
+// drawFromTalon has every player draw a card from the talon, in seat order
+// from the winner of the trick, while it holds cards.
+func (h *Hand) drawFromTalon(winner PlayerId) {
+	if h.Talon == 0 {
+		return
+	}
+
+	player := winner
+	for range h.table().Players() {
+		card, err := h.dealer.DealCard()
+		if err != nil {
+			panic(err)
+		}
+		h.addCard(player, card)
+		h.Talon--
+		player = h.table().Next(player)
+	}
+}
+
+func (h *Hand) scoreBonuses(lastTrickWinner TeamId) {
+	if h.rules.CapotPoints > 0 && h.hasTakenAllTricks(lastTrickWinner) {
+		h.Totals[lastTrickWinner] += h.rules.CapotPoints - h.TrickPoints[lastTrickWinner]
+		h.Bonuses = append(h.Bonuses, HandBonus{Type: CapotBonus, Team: lastTrickWinner, Points: h.rules.CapotPoints})
+		return
+	}
+
+	if h.rules.LastTrickPoints > 0 {
+		h.Totals[lastTrickWinner] += h.rules.LastTrickPoints
+		h.Bonuses = append(h.Bonuses, HandBonus{Type: LastTrickBonus, Team: lastTrickWinner, Points: h.rules.LastTrickPoints})
+	}
+}
+
+// scoreContract applies the inside rule: takers scoring less than the best
+// defenders lose all their points to them, and on a tie the takers' points
+// hang until the next hand is won. Points lost to defenders tied for best are
+// shared between them. French takers keep their Belote either way. Coinche
+// hands are scored against their bid instead, and Bulgarian totals are
+// rounded to tens first.
+func (h *Hand) scoreContract() {
+	switch h.rules.Mode {
+	case CoincheMode:
+		h.scoreBid()
+		return
+	case BulgarianMode:
+		h.roundTotals()
+	}
+	if !h.rules.InsideRule || h.TakerTeam == NoTeamId {
+		h.ContractOutcome = ContractMade
+		return
+	}
+
+	defenders := h.bestDefenders()
+	best := h.Totals[defenders[0]]
+	kept := 0
+	if h.rules.Mode == FrenchMode {
+		kept = h.BelotePoints[h.TakerTeam]
+	}
+	switch {
+	case h.Totals[h.TakerTeam] < best:
+		h.sharePoints(defenders, h.Totals[h.TakerTeam]-kept)
+		h.Totals[h.TakerTeam] = kept
+		h.ContractOutcome = ContractInside
+	case h.Totals[h.TakerTeam] == best:
+		h.HungPoints = h.Totals[h.TakerTeam] - kept
+		h.Totals[h.TakerTeam] = kept
+		h.ContractOutcome = ContractHung
+	default:
+		h.ContractOutcome = ContractMade
+	}
+}
+
+// sharePoints splits points between teams, the first taking what does not
+// divide.
+func (h *Hand) sharePoints(teams []TeamId, points int) {
+	for i, team := range teams {
+		h.Totals[team] += points / len(teams)
+		if i == 0 {
+			h.Totals[team] += points % len(teams)
+		}
+	}
+}
+
+// defenders returns the teams defending against the takers, in seat order
+// from the taker.
+func (h *Hand) defenders() []TeamId {
+	var defenders []TeamId
+	player := h.Taker
+	for range h.table().Players() {
+		player = h.table().Next(player)
+		team := h.table().Team(player)
+		if team != h.TakerTeam && !slices.Contains(defenders, team) {
+			defenders = append(defenders, team)
+		}
+	}
+	return defenders
+}
+
+// bestDefenders returns the defending teams with the most points, in seat
+// order from the taker.
+func (h *Hand) bestDefenders() []TeamId {
+	var best []TeamId
+	for _, team := range h.defenders() {
+		switch {
+		case len(best) == 0 || h.Totals[team] > h.Totals[best[0]]:
+			best = []TeamId{team}
+		case h.Totals[team] == h.Totals[best[0]]:
+			best = append(best, team)
+		}
+	}
+	return best
+}
+
+// getWinner returns the team that scored the most in a finished hand, if no
+// other team scored as much.
+func (h *Hand) getWinner() TeamId {
+	winner := NoTeamId
+	for _, team := range h.table().Teams() {
+		switch {
+		case winner == NoTeamId || h.Totals[team] > h.Totals[winner]:
+			winner = team
+		case h.Totals[team] == h.Totals[winner]:
+			return NoTeamId
+		}
+	}
+	return winner
+}
+
+func (h *Hand) hasTakenAllTricks(team TeamId) bool {
+	for _, trick := range h.CompletedTricks {
+		result, err := trick.GetTrickResult()
+		if err != nil {
+			panic(err)
+		}
+		if h.table().Team(result.WinnerPlayer) != team {
+			return false
+		}
+	}
+	return true
 }
 
 func (h *Hand) checkEndCondition() bool {
-	return len(h.PlayerCards[Player1]) == 0
+	return h.PlayerCards[Player1].IsEmpty()
 }
 
 func (h *Hand) getCurrentTrumpSelectionTurn(selections map[PlayerId]bool) (PlayerId, error) {
 	playerId := h.StartingPlayer
-	for i := 0; i < NUM_PLAYERS; i++ {
+	for range h.table().Players() {
 		if !selections[playerId] {
 			return playerId, nil
 		}
-		playerId = playerId.GetNextPlayerId()
+		playerId = h.table().Next(playerId)
 	}
 	return Player1, fmt.Errorf("all players have selected")
 }
@@ -267,84 +744,151 @@ func (h *Hand) checkIsTrumpSelectionTurnFor(player PlayerId, selections map[Play
 	return nil
 }
 
-func (h *Hand) handleTrumpSelected(trump Suit) {
-	h.Trump = trump
-	h.State = HandInProgress
-	h.CurrentTrick = NewTrick(h.StartingPlayer, h.Trump)
-	h.dealCards()
-}
-
-func (h *Hand) dealCards() {
-	for player := Player1; player <= Player4; player++ {
-		for len(h.PlayerCards[player]) < NUM_CARDS_PER_PLAYER {
-			cardDealt, err := h.dealer.DealCard()
-			if err != nil {
-				panic(err)
-			}
-			h.PlayerCards[player][cardDealt] = true
+// getCurrentDoublingTurn returns the first of the takers, or of the
+// defenders, in seat order from the starting player, who has not answered
+// yet.
+func (h *Hand) getCurrentDoublingTurn(takers bool, selections map[PlayerId]bool) (PlayerId, error) {
+	playerId := h.StartingPlayer
+	for range h.table().Players() {
+		if h.isTaker(playerId) == takers && !selections[playerId] {
+			return playerId, nil
 		}
+		playerId = h.table().Next(playerId)
 	}
+	return Player1, fmt.Errorf("all players have answered")
 }
 
-func makePlayerCards() map[PlayerId]map[Card]bool {
-	playerCards := make(map[PlayerId]map[Card]bool, NUM_PLAYERS)
-	for player := Player1; player <= Player4; player++ {
-		playerCards[player] = make(map[Card]bool, NUM_CARDS_PER_PLAYER)
+func (h *Hand) checkIsDoublingTurnFor(player PlayerId, takers bool, selections map[PlayerId]bool) error {
+	if h.isTaker(player) != takers {
+		return fmt.Errorf("player's team cannot answer now")
 	}
-	return playerCards
+
+	if selections[player] {
+		return fmt.Errorf("player has already answered")
+	}
+
+	currentPlayer, err := h.getCurrentDoublingTurn(takers, selections)
+	if err != nil {
+		panic(err)
+	}
+
+	if player != currentPlayer {
+		return fmt.Errorf("not player's turn")
+	}
+
+	return nil
 }
 
-func (h *Hand) scorePreHandDeclarations() {
-	bestByPlayer := map[PlayerId]*PreHandDeclaration{}
+// isTaker tells whether player plays for the team that took the hand.
+func (h *Hand) isTaker(player PlayerId) bool {
+	return h.table().Team(player) == h.TakerTeam
+}
 
-	for player, decls := range h.PlayerDeclarations {
-		for _, decl := range decls {
-			prehand, ok := decl.(PreHandDeclaration)
-			if !ok {
-				continue
-			}
-			if best := bestByPlayer[player]; best == nil || CompareDeclarations(prehand, *best, h.Trump) > 0 {
-				r := prehand
-				bestByPlayer[player] = &r
-			}
-		}
+func (h *Hand) handleTrumpSelected(taker PlayerId, trump Contract) {
+	h.Taker = taker
+	h.TakerTeam = h.table().Team(taker)
+	h.Trump = trump
+	h.State = HandInProgress
+	if h.rules.ContraAllowed {
+		h.State = ContraSelection
 	}
+	h.CurrentTrick = NewTrick(h.StartingPlayer, h.Trump, h.table())
+	h.dealCards()
+}
 
-	var winnerPlayer *PlayerId
-	playerId := h.StartingPlayer
-	for i := 0; i < NUM_PLAYERS; i++ {
-		best := bestByPlayer[playerId]
-		if best != nil {
-			if winnerPlayer == nil || CompareDeclarations(*best, *bestByPlayer[*winnerPlayer], h.Trump) > 0 {
-				p := playerId
-				winnerPlayer = &p
-			}
+// dealCards deals every player up to their full hand. Hands dealt in packets
+// are dealt in playing order from the starting player, the others from
+// Player1.
+func (h *Hand) dealCards() {
+	players := h.table().PlayerIds()
+	if h.rules.dealsInPackets() {
+		players = h.table().PlayerIdsFrom(h.StartingPlayer)
+	}
+	for _, player := range players {
+		for h.PlayerCards[player].Len() < NUM_CARDS_PER_PLAYER {
+			h.addCard(player, h.dealCard())
 		}
-		playerId = playerId.GetNextPlayerId()
+
+		h.InitialCards[player] = h.PlayerCards[player].Cards()
 	}
+}
 
-	var winner *TeamId
-	if winnerPlayer != nil {
-		t := winnerPlayer.GetTeam()
-		winner = &t
+func makePlayerCards(table Table) map[PlayerId]CardSet {
+	playerCards := make(map[PlayerId]CardSet, table.Players())
+	for _, player := range table.PlayerIds() {
+		playerCards[player] = 0
 	}
+	return playerCards
+}
 
+func (h *Hand) addCard(player PlayerId, card Card) {
+	h.PlayerCards[player] = h.PlayerCards[player].With(card)
+}
+
+func (h *Hand) scorePreHandDeclarations() {
+	winner := DeclarationWinner(h.PlayerDeclarations, h.StartingPlayer, h.Trump, h.table())
 	h.DeclarationWinner = winner
+	h.revealDeclarationCards()
 
 	if winner != nil {
 		for player, decls := range h.PlayerDeclarations {
-			if player.GetTeam() != *winner {
+			if h.table().Team(player) != *winner {
 				continue
 			}
 			for _, decl := range decls {
+				// Belote is normally scored when announced. Without the
+				// declaration win it only counts once the winner is known.
 				if _, ok := decl.(Belote); !ok {
-					h.Totals[*winner] += decl.Points()
+					h.Totals[*winner] += h.rules.DeclarationValue(decl)
+					h.DeclarationPoints[*winner] += h.rules.DeclarationValue(decl)
+				} else if !h.rules.BeloteCountsWithoutDeclarationWin {
+					h.Totals[*winner] += h.rules.BelotePoints
+					h.BelotePoints[*winner] += h.rules.BelotePoints
 				}
 			}
 		}
 	}
 }
 
+// revealDeclarationCards shows the cards of the winning team's pre-hand
+// declarations at the end of the first trick, as they were held when
+// announced.
+func (h *Hand) revealDeclarationCards() {
+	h.RevealedCards = map[PlayerId]CardSet{}
+	if h.DeclarationWinner == nil {
+		return
+	}
+
+	for player, decls := range h.PlayerDeclarations {
+		if h.table().Team(player) != *h.DeclarationWinner {
+			continue
+		}
+		held := h.PlayerCards[player].With(h.CurrentTrick.Cards[player])
+		var revealed CardSet
+		for _, decl := range decls {
+			if d, ok := decl.(PreHandDeclaration); ok {
+				revealed = revealed.Union(declarationCards(d, held))
+			}
+		}
+		if !revealed.IsEmpty() {
+			h.RevealedCards[player] = revealed
+		}
+	}
+}
+
+func (h *Hand) scoreBelote(player PlayerId) {
+	team := h.table().Team(player)
+	if !h.rules.BeloteCountsWithoutDeclarationWin && (h.DeclarationWinner == nil || *h.DeclarationWinner != team) {
+		return
+	}
+	h.Totals[team] += h.rules.BelotePoints
+	h.BelotePoints[team] += h.rules.BelotePoints
+}
+
 func (h *Hand) getLastPlayer() PlayerId {
-	return h.StartingPlayer.GetPreviousPlayerId()
+	return h.table().Previous(h.StartingPlayer)
+}
+
+func (h *Hand) table() Table {
+	return h.rules.Table()
 }
@@ -0,0 +1,547 @@
+package game
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLegalCardsFollowTrickRules(t *testing.T) {
+	hand := &Hand{
+		State: HandInProgress,
+		CurrentTrick: &Trick{
+			StartingPlayer: Player1,
+			Cards: map[PlayerId]Card{
+				Player1: {Suit: Hearts, Rank: Eight},
+				Player2: {Suit: Diamonds, Rank: Queen},
+			},
+			Trump: TrumpContract(Diamonds),
+		},
+		PlayerCards: map[PlayerId]CardSet{
+			Player3: NewCardSet(
+				Card{Suit: Diamonds, Rank: King},
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Diamonds, Rank: Ace},
+				Card{Suit: Clubs, Rank: Ten},
+			),
+		},
+		Trump: TrumpContract(Diamonds),
+	}
+
+	expected := []Card{
+		{Suit: Diamonds, Rank: King},
+		{Suit: Diamonds, Rank: Ace},
+	}
+	if got := hand.LegalCards(Player3); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := hand.LegalCards(Player4); len(got) != 0 {
+		t.Errorf("expected no legal cards out of turn, got %v", got)
+	}
+}
+
+func TestLegalCardsMatchPlayCard(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(17))
+	gm.Start()
+	if gm.GetHand().GetState() == TableTrumpSelection {
+		if err := gm.AcceptTableTrump(gm.GetHand().StartingPlayer, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	passDoubling(t, &gm)
+
+	hand := gm.GetHand()
+	for hand.GetState() == HandInProgress {
+		player, _ := hand.GetCurrentTurn()
+		legal := hand.LegalCards(player)
+		if len(legal) == 0 {
+			t.Fatalf("player %d has no legal cards", player)
+		}
+
+		for _, card := range hand.GetPlayerCards(player).Cards() {
+			err := hand.CurrentTrick.validateCard(card, hand.GetPlayerCards(player))
+			if isLegal := slices.Contains(legal, card); isLegal != (err == nil) {
+				t.Fatalf("card %v: legal=%v but validateCard returned %v", card, isLegal, err)
+			}
+		}
+
+		if err := gm.PlayCard(player, legal[len(legal)-1], Announcement{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLegalActionsDuringTableTrumpSelection(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	hand := gm.GetHand()
+
+	actions := hand.LegalActions(Player1)
+	if len(actions) != 2 || actions[0].Type != AcceptTableTrumpAction {
+		t.Fatalf("expected accept and refuse actions, got %v", actions)
+	}
+	if len(hand.LegalActions(Player2)) != 0 {
+		t.Errorf("expected no actions out of turn")
+	}
+}
+
+func TestLegalActionsDuringFreeTrumpSelection(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	hand := gm.GetHand()
+
+	for _, player := range []PlayerId{Player1, Player2, Player3, Player4} {
+		if err := gm.AcceptTableTrump(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actions := hand.LegalActions(Player1)
+	if len(actions) != NUM_SUITS || actions[0].Suit != nil {
+		t.Fatalf("expected a pass and three suits, got %v", actions)
+	}
+	for _, action := range actions[1:] {
+		if *action.Suit == hand.GetTableTrump().Suit {
+			t.Errorf("table trump suit must not be offered")
+		}
+	}
+
+	for _, player := range []PlayerId{Player1, Player2, Player3} {
+		if err := gm.SelectTrump(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actions = hand.LegalActions(Player4)
+	if len(actions) != NUM_SUITS-1 {
+		t.Fatalf("expected the last player to have to pick one of three suits, got %v", actions)
+	}
+	for _, action := range actions {
+		if action.Suit == nil {
+			t.Errorf("last player must not be offered a pass")
+		}
+	}
+}
+
+func newLastTrickHand(completedTrickWinners []PlayerId) *Hand {
+	rules, _ := GetRuleSetPreset(BonusesRuleSetPreset)
+	hand := &Hand{
+		State:          HandInProgress,
+		CurrentTrick:   NewTrick(Player1, TrumpContract(Spades), FourPlayerTable),
+		StartingPlayer: Player1,
+		Totals:         map[TeamId]int{Team1: 0, Team2: 0},
+		TrickPoints:    map[TeamId]int{Team1: 0, Team2: 0},
+		PlayerCards: map[PlayerId]CardSet{
+			Player1: NewCardSet(Card{Suit: Spades, Rank: Jack}),
+			Player2: NewCardSet(Card{Suit: Hearts, Rank: Seven}),
+			Player3: NewCardSet(Card{Suit: Hearts, Rank: Eight}),
+			Player4: NewCardSet(Card{Suit: Hearts, Rank: Nine}),
+		},
+		PlayerDeclarations: map[PlayerId][]Declaration{},
+		Trump:              TrumpContract(Spades),
+		rules:              rules,
+	}
+
+	for _, winner := range completedTrickWinners {
+		trick := NewTrick(winner, TrumpContract(Spades), FourPlayerTable)
+		for player := Player1; player <= Player4; player++ {
+			trick.Cards[player] = Card{Suit: Clubs, Rank: Seven}
+		}
+		trick.Cards[winner] = Card{Suit: Spades, Rank: Ace}
+		hand.CompletedTricks = append(hand.CompletedTricks, trick)
+		hand.Totals[winner.GetTeam()] += 11
+		hand.TrickPoints[winner.GetTeam()] += 11
+	}
+	hand.PreviousTrick = hand.CompletedTricks[len(hand.CompletedTricks)-1]
+
+	return hand
+}
+
+func playLastTrick(t *testing.T, hand *Hand) {
+	t.Helper()
+
+	for player := Player1; player <= Player4; player++ {
+		for _, card := range hand.PlayerCards[player].Cards() {
+			if err := hand.PlayCard(player, card, Announcement{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if hand.GetState() != HandFinished {
+		t.Fatalf("expected hand to be finished, got %s", hand.GetState())
+	}
+}
+
+func TestLastTrickBonus(t *testing.T) {
+	hand := newLastTrickHand([]PlayerId{Player1, Player2, Player1, Player3, Player1, Player1, Player1})
+	playLastTrick(t, hand)
+
+	expected := []HandBonus{{Type: LastTrickBonus, Team: Team1, Points: 10}}
+	if !slices.Equal(hand.Bonuses, expected) {
+		t.Errorf("expected bonuses %v, got %v", expected, hand.Bonuses)
+	}
+	if hand.TrickPoints[Team1] != 6*11+20 || hand.Totals[Team1] != 6*11+20+10 {
+		t.Errorf("unexpected trick points %v and totals %v", hand.TrickPoints, hand.Totals)
+	}
+}
+
+func TestCapotReplacesTrickPoints(t *testing.T) {
+	hand := newLastTrickHand([]PlayerId{Player1, Player3, Player1, Player3, Player1, Player1, Player1})
+	playLastTrick(t, hand)
+
+	expected := []HandBonus{{Type: CapotBonus, Team: Team1, Points: 250}}
+	if !slices.Equal(hand.Bonuses, expected) {
+		t.Errorf("expected bonuses %v, got %v", expected, hand.Bonuses)
+	}
+	if hand.Totals[Team1] != 250 || hand.Totals[Team2] != 0 {
+		t.Errorf("expected capot totals, got %v", hand.Totals)
+	}
+}
+
+func TestBonusesDisabledByDefault(t *testing.T) {
+	hand := newLastTrickHand([]PlayerId{Player1, Player3, Player1, Player3, Player1, Player1, Player1})
+	hand.rules = DefaultRuleSet()
+	playLastTrick(t, hand)
+
+	if len(hand.Bonuses) != 0 || hand.Totals[Team1] != 7*11+20 {
+		t.Errorf("expected no bonuses, got %v with totals %v", hand.Bonuses, hand.Totals)
+	}
+}
+
+func TestContractOutcome(t *testing.T) {
+	testCases := []struct {
+		name            string
+		totals          map[TeamId]int
+		expectedTotals  map[TeamId]int
+		expectedOutcome ContractOutcome
+		expectedHung    int
+	}{
+		{
+			name:            "Takers score more",
+			totals:          map[TeamId]int{Team1: 100, Team2: 62},
+			expectedTotals:  map[TeamId]int{Team1: 100, Team2: 62},
+			expectedOutcome: ContractMade,
+		},
+		{
+			name:            "Takers go inside",
+			totals:          map[TeamId]int{Team1: 70, Team2: 92},
+			expectedTotals:  map[TeamId]int{Team1: 0, Team2: 162},
+			expectedOutcome: ContractInside,
+		},
+		{
+			name:            "Tie hangs",
+			totals:          map[TeamId]int{Team1: 81, Team2: 81},
+			expectedTotals:  map[TeamId]int{Team1: 0, Team2: 81},
+			expectedOutcome: ContractHung,
+			expectedHung:    81,
+		},
+	}
+
+	rules, _ := GetRuleSetPreset(InsideRuleSetPreset)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hand := &Hand{Totals: tc.totals, Taker: Player3, TakerTeam: Team1, rules: rules}
+			hand.scoreContract()
+
+			if hand.ContractOutcome != tc.expectedOutcome {
+				t.Errorf("expected outcome %s, got %s", tc.expectedOutcome, hand.ContractOutcome)
+			}
+			if !maps.Equal(hand.Totals, tc.expectedTotals) {
+				t.Errorf("expected totals %v, got %v", tc.expectedTotals, hand.Totals)
+			}
+			if hand.HungPoints != tc.expectedHung {
+				t.Errorf("expected %d hung points, got %d", tc.expectedHung, hand.HungPoints)
+			}
+		})
+	}
+}
+
+func TestInsideRuleDisabledByDefault(t *testing.T) {
+	hand := &Hand{Totals: map[TeamId]int{Team1: 70, Team2: 92}, Taker: Player1, TakerTeam: Team1, rules: DefaultRuleSet()}
+	hand.scoreContract()
+
+	if hand.ContractOutcome != ContractMade || hand.Totals[Team1] != 70 {
+		t.Errorf("expected totals to be kept, got %s with %v", hand.ContractOutcome, hand.Totals)
+	}
+}
+
+func TestFrenchInsideKeepsBelote(t *testing.T) {
+	testCases := []struct {
+		name           string
+		totals         map[TeamId]int
+		expectedTotals map[TeamId]int
+		expectedHung   int
+	}{
+		{
+			name:           "Takers go inside",
+			totals:         map[TeamId]int{Team1: 70, Team2: 92},
+			expectedTotals: map[TeamId]int{Team1: 20, Team2: 142},
+		},
+		{
+			name:           "Tie hangs",
+			totals:         map[TeamId]int{Team1: 81, Team2: 81},
+			expectedTotals: map[TeamId]int{Team1: 20, Team2: 81},
+			expectedHung:   61,
+		},
+	}
+
+	rules, _ := GetRuleSetPreset(FrenchRuleSetPreset)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hand := &Hand{
+				Totals:       tc.totals,
+				BelotePoints: map[TeamId]int{Team1: 20},
+				Taker:        Player3,
+				TakerTeam:    Team1,
+				rules:        rules,
+			}
+			hand.scoreContract()
+
+			if !maps.Equal(hand.Totals, tc.expectedTotals) || hand.HungPoints != tc.expectedHung {
+				t.Errorf("expected totals %v with %d hung, got %v with %d", tc.expectedTotals, tc.expectedHung, hand.Totals, hand.HungPoints)
+			}
+		})
+	}
+}
+
+func TestFrenchDealUsesPackets(t *testing.T) {
+	rules, _ := GetRuleSetPreset(FrenchRuleSetPreset)
+	deck := NewDeck()
+	hand := NewHand(Player2, NewFixedDealer(deck), rules)
+
+	if hand.GetTableTrump() != deck[20] {
+		t.Fatalf("expected the card after the packets to be turned, got %v", hand.GetTableTrump())
+	}
+	if expected := NewCardSet(deck[3], deck[4], deck[5], deck[14], deck[15]); hand.PlayerCards[Player3] != expected {
+		t.Errorf("expected player 3 to be dealt packets of 3 and 2, got %v", hand.PlayerCards[Player3].Cards())
+	}
+
+	if err := hand.AcceptTableTrump(Player2, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := hand.AcceptTableTrump(Player3, true); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[PlayerId]CardSet{
+		Player1: NewCardSet(deck[9], deck[10], deck[11], deck[18], deck[19], deck[29], deck[30], deck[31]),
+		Player2: NewCardSet(deck[0], deck[1], deck[2], deck[12], deck[13], deck[21], deck[22], deck[23]),
+		Player3: NewCardSet(deck[3], deck[4], deck[5], deck[14], deck[15], deck[20], deck[24], deck[25]),
+		Player4: NewCardSet(deck[6], deck[7], deck[8], deck[16], deck[17], deck[26], deck[27], deck[28]),
+	}
+	for player, cards := range expected {
+		if hand.PlayerCards[player] != cards {
+			t.Errorf("expected player %d to hold %v, got %v", player, cards.Cards(), hand.PlayerCards[player].Cards())
+		}
+	}
+}
+
+func TestTakerIsRecorded(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+
+	if err := gm.AcceptTableTrump(Player1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.AcceptTableTrump(Player2, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if hand := gm.GetHand(); hand.Taker != Player2 || hand.TakerTeam != Team2 {
+		t.Errorf("expected Player2 of Team2 to be the taker, got %d of %d", hand.Taker, hand.TakerTeam)
+	}
+}
+
+func newContraHand(t *testing.T) *BeloteGame {
+	t.Helper()
+
+	rules, _ := GetRuleSetPreset(ContraRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	if err := gm.AcceptTableTrump(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+	return &gm
+}
+
+func TestContraAndRecontra(t *testing.T) {
+	gm := newContraHand(t)
+	hand := gm.GetHand()
+
+	if err := gm.CallContra(Player3, true); err == nil {
+		t.Errorf("expected takers not to be able to call contra")
+	}
+	if err := gm.CallContra(Player4, true); err == nil {
+		t.Errorf("expected Player4 to wait for Player2")
+	}
+	if actions := hand.LegalActions(Player2); len(actions) != 2 || actions[0].Type != ContraAction {
+		t.Errorf("expected contra actions, got %v", actions)
+	}
+
+	if err := gm.CallContra(Player2, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.CallContra(Player4, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.GetState() != RecontraSelection || hand.Multiplier != 2 {
+		t.Fatalf("expected recontra selection with a doubled hand, got %s x%d", hand.GetState(), hand.Multiplier)
+	}
+
+	if err := gm.CallRecontra(Player2, true); err == nil {
+		t.Errorf("expected defenders not to be able to call recontra")
+	}
+	if err := gm.CallRecontra(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+	if hand.GetState() != HandInProgress || hand.Multiplier != 4 {
+		t.Errorf("expected hand in progress with a quadrupled hand, got %s x%d", hand.GetState(), hand.Multiplier)
+	}
+	if err := gm.CallContra(Player2, true); err == nil {
+		t.Errorf("expected contra to be closed once the hand is in progress")
+	}
+}
+
+func TestContraPassedByDefenders(t *testing.T) {
+	gm := newContraHand(t)
+	hand := gm.GetHand()
+
+	for _, player := range []PlayerId{Player2, Player4} {
+		if err := gm.CallContra(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if hand.GetState() != HandInProgress || hand.Multiplier != 1 {
+		t.Errorf("expected an undoubled hand in progress, got %s x%d", hand.GetState(), hand.Multiplier)
+	}
+}
+
+func TestRecontraPassedByTakers(t *testing.T) {
+	gm := newContraHand(t)
+	hand := gm.GetHand()
+
+	if err := gm.CallContra(Player2, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range []PlayerId{Player1, Player3} {
+		if err := gm.CallRecontra(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if hand.GetState() != HandInProgress || hand.Multiplier != 2 {
+		t.Errorf("expected a doubled hand in progress, got %s x%d", hand.GetState(), hand.Multiplier)
+	}
+}
+
+// playFirstLegalCard plays the first legal card of the player whose turn it is.
+func playFirstLegalCard(t *testing.T, hand *Hand) {
+	t.Helper()
+
+	player, err := hand.GetCurrentTurn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hand.PlayCard(player, hand.LegalCards(player)[0], Announcement{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandCloneIsIndependent(t *testing.T) {
+	gm := newContraHand(t)
+	passDoubling(t, gm)
+	hand := gm.GetHand()
+	for i := 0; i < 6; i++ {
+		playFirstLegalCard(t, hand)
+	}
+
+	clone := hand.Clone()
+	if !reflect.DeepEqual(clone, hand) {
+		t.Fatalf("expected clone to equal the hand")
+	}
+	if clone.PreviousTrick != clone.CompletedTricks[len(clone.CompletedTricks)-1] {
+		t.Errorf("expected the previous trick to stay the last completed trick")
+	}
+
+	for clone.GetState() != HandFinished {
+		playFirstLegalCard(t, clone)
+	}
+
+	if len(hand.CompletedTricks) != 1 || len(hand.CurrentTrick.Cards) != 2 {
+		t.Errorf("expected playing the clone to leave the hand untouched")
+	}
+	remaining := 0
+	for player := Player1; player <= Player4; player++ {
+		remaining += hand.GetPlayerCards(player).Len()
+	}
+	if remaining != NUM_PLAYERS*NUM_CARDS_PER_PLAYER-6 {
+		t.Errorf("expected %d cards left in the hand, got %d", NUM_PLAYERS*NUM_CARDS_PER_PLAYER-6, remaining)
+	}
+}
+
+func TestNewHandFromView(t *testing.T) {
+	gm := newContraHand(t)
+	passDoubling(t, gm)
+	hand := gm.GetHand()
+	for i := 0; i < 5; i++ {
+		playFirstLegalCard(t, hand)
+	}
+
+	view := hand.GetPlayerView(Player3)
+	rebuilt, err := NewHandFromView(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for player := Player1; player <= Player4; player++ {
+		if player != Player3 && !rebuilt.PlayerCards[player].IsEmpty() {
+			t.Errorf("expected player %d's cards to be hidden", player)
+		}
+		rebuilt.PlayerCards[player] = hand.PlayerCards[player]
+	}
+
+	if !maps.Equal(rebuilt.TrickPoints, hand.TrickPoints) {
+		t.Errorf("expected trick points %v, got %v", hand.TrickPoints, rebuilt.TrickPoints)
+	}
+
+	for hand.GetState() != HandFinished {
+		playFirstLegalCard(t, hand)
+		playFirstLegalCard(t, rebuilt)
+	}
+	if !maps.Equal(rebuilt.Totals, hand.Totals) {
+		t.Errorf("expected rebuilt hand to finish with totals %v, got %v", hand.Totals, rebuilt.Totals)
+	}
+
+	if _, err := NewHandFromView(hand.GetPlayerView(Player3)); err == nil {
+		t.Errorf("expected a finished hand not to be rebuilt")
+	}
+}
+
+func BenchmarkPlayFullHand(b *testing.B) {
+	rules := DefaultRuleSet()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		hand := NewHand(Player1, NewSeededDealer(uint64(i)), rules)
+		for hand.GetState() != HandFinished {
+			player, err := hand.GetCurrentTurn()
+			if err != nil {
+				b.Fatal(err)
+			}
+			switch hand.GetState() {
+			case TableTrumpSelection:
+				err = hand.AcceptTableTrump(player, true)
+			case ContraSelection:
+				err = hand.CallContra(player, false)
+			case RecontraSelection:
+				err = hand.CallRecontra(player, false)
+			case HandInProgress:
+				err = hand.PlayCard(player, hand.LegalCards(player)[0], Announcement{})
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
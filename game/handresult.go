@@ -0,0 +1,70 @@
+package game
+
+import (
+	"maps"
+	"slices"
+)
+
+// HandResult is the score sheet entry of a finished hand, breaking down where
+// every credited point came from.
+type HandResult struct {
+	HandNumber int      `json:"handNumber"`
+	Trump      Contract `json:"trump"`
+	Taker      PlayerId `json:"taker"`
+	TakerTeam  TeamId   `json:"takerTeam"`
+	// Bid is the contract of a Coinche hand.
+	Bid *Bid `json:"bid,omitempty"`
+
+	TrickPoints       map[TeamId]int `json:"trickPoints"`
+	DeclarationPoints map[TeamId]int `json:"declarationPoints"`
+	BelotePoints      map[TeamId]int `json:"belotePoints"`
+	Bonuses           []HandBonus    `json:"bonuses"`
+
+	Outcome    ContractOutcome `json:"outcome"`
+	Multiplier int             `json:"multiplier"`
+
+	// Totals are the hand points after the contract rules, before the
+	// multiplier is applied.
+	Totals map[TeamId]int `json:"totals"`
+
+	// HungPoints are left hanging by this hand, CarriedPoints are hanging
+	// points of earlier hands credited to this hand's winner.
+	HungPoints    int `json:"hungPoints"`
+	CarriedPoints int `json:"carriedPoints"`
+
+	// Credited is what the hand added to each team's score.
+	Credited map[TeamId]int `json:"credited"`
+	Scores   map[TeamId]int `json:"scores"`
+}
+
+func newHandResult(handNumber int, hand *Hand) HandResult {
+	credited := map[TeamId]int{}
+	for team, total := range hand.Totals {
+		credited[team] = total * hand.Multiplier
+	}
+
+	var bid *Bid
+	if last := hand.lastBid(); last != nil {
+		b := *last
+		bid = &b
+	}
+
+	return HandResult{
+		HandNumber:        handNumber,
+		Trump:             hand.Trump,
+		Taker:             hand.Taker,
+		TakerTeam:         hand.TakerTeam,
+		Bid:               bid,
+		TrickPoints:       maps.Clone(hand.TrickPoints),
+		DeclarationPoints: maps.Clone(hand.DeclarationPoints),
+		BelotePoints:      maps.Clone(hand.BelotePoints),
+		Bonuses:           slices.Clone(hand.Bonuses),
+		Outcome:           hand.ContractOutcome,
+		Multiplier:        hand.Multiplier,
+		Totals:            maps.Clone(hand.Totals),
+		HungPoints:        hand.HungPoints * hand.Multiplier,
+		CarriedPoints:     0,
+		Credited:          credited,
+		Scores:            nil,
+	}
+}
@@ -0,0 +1,1045 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The belote notation writes a game down as text, in the spirit of chess PGN.
+// Tags come first, one per line, then every hand in turn:
+//
+//	[Date "2026.10.18"]
+//	[Player1 "Ani"]
+//	[StartingPlayer "1"]
+//	[Rules "standard"]
+//
+//	Hand 0
+//	Deal: 8H | 7S 8S 9S 10S JS QS KS AS ...
+//	Trump: P1 pass, P2 take
+//	Contra: P3 pass, P1 pass
+//	1. P1: AS 7S 8S 9S
+//	...
+//	Declarations: P1 tierce KS, P2 belote KH
+//	Result: 162 0
+//	Continue: P1 P2 P3 P4
+//
+// The deal lists the deck in the order it was dealt, the table trump card
+// first. A deal in packets turns it after the first five cards of every
+// player, and writes it between bars. With realistic dealing every deal
+// follows the cut it was dealt after, as the player cutting and the number of
+// cards lifted:
+//
+//	Cut: P3 14
+//
+// Trump decisions are "take" or "pass" on the table trump card, then a suit
+// letter, "NT" for no trumps, "AT" for all trumps or "pass" in the free
+// selection; doubling answers are "call" or "pass". A Coinche hand has no
+// table trump card and starts with its auction instead:
+//
+//	Bids: P1 80 H, P2 pass, P3 capot NT, P4 pass, P1 pass, P2 pass
+//
+// where a bid is its points, or "capot", and its contract. The coinche and
+// surcoinche are written as a contra and a recontra. A Bulgarian hand also
+// starts with its auction, after five cards were dealt, where a bid is only a
+// contract and doubles are written in turn as "double" and "redouble":
+//
+//	Bids: P1 H, P2 double, P3 pass, P4 NT, P1 pass, P2 pass, P3 pass
+//
+// A hand in which every player passed is followed by its new deal, under the
+// same number. Cards are written as their rank and suit letter, and every
+// trick starts with its number and leader. The declarations line lists what
+// each player announced: pre-hand declarations go with their first card and
+// Belote with the card it is written with. The result line
+// gives the points credited to each team, one per seat at a table without
+// partnerships; it only restates what the cards imply and is checked when
+// parsing. Continue lists the players who acknowledged the hand summary,
+// followed by "forced" when it was ended without them. Lines starting with
+// ";" are comments.
+const (
+	DateTag           = "Date"
+	SeedTag           = "Seed"
+	RulesTag          = "Rules"
+	StartingPlayerTag = "StartingPlayer"
+)
+
+var (
+	notationSuits = map[Suit]string{Spades: "S", Hearts: "H", Diamonds: "D", Clubs: "C"}
+
+	notationContracts = map[ContractMode]string{NoTrumps: "NT", AllTrumps: "AT"}
+
+	notationDeclarations = map[PreHandDeclarationType]string{
+		Tierce:     "tierce",
+		Quarte:     "quarte",
+		Quinte:     "quinte",
+		Carre:      "carre",
+		NinesCarre: "nines-carre",
+		JacksCarre: "jacks-carre",
+	}
+
+	rulePresets = []string{
+		StandardRuleSetPreset, QuickRuleSetPreset, StrictRuleSetPreset,
+		ContraRuleSetPreset, BonusesRuleSetPreset, InsideRuleSetPreset,
+		RedealRuleSetPreset, ContractsRuleSetPreset,
+		TwoPlayerRuleSetPreset, ThreePlayerRuleSetPreset,
+		CoincheRuleSetPreset, BulgarianRuleSetPreset, FrenchRuleSetPreset,
+		RealisticRuleSetPreset,
+	}
+)
+
+const (
+	beloteNotation = "belote"
+	forcedNotation = "forced"
+	capotNotation  = "capot"
+
+	doubleNotation   = "double"
+	redoubleNotation = "redouble"
+)
+
+// PlayerTag returns the tag naming the player in seat player.
+func PlayerTag(player PlayerId) string {
+	return fmt.Sprintf("Player%d", player)
+}
+
+// WriteNotation writes gm in the belote notation, with tags written before
+// the game. The rules and starting player tags are always taken from gm.
+func WriteNotation(w io.Writer, gm *BeloteGame, tags map[string]string) error {
+	if len(gm.events) == 0 {
+		return fmt.Errorf("notation: game has not started")
+	}
+	started := gm.events[0].(GameStartedEvent)
+
+	tags = maps.Clone(tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	tags[StartingPlayerTag] = strconv.Itoa(int(started.StartingPlayer))
+	tags[RulesTag] = formatRulesTag(started.Rules)
+
+	writer := notationWriter{w: bufio.NewWriter(w)}
+	for _, name := range sortedTagNames(tags, started.Rules.Table()) {
+		writer.printf("[%s %s]\n", name, strconv.Quote(tags[name]))
+	}
+
+	if err := writer.writeHands(gm); err != nil {
+		return err
+	}
+	return writer.w.Flush()
+}
+
+// ParseNotation reads a game written in the belote notation and rebuilds it,
+// with the tags written along with it.
+func ParseNotation(r io.Reader) (*BeloteGame, map[string]string, error) {
+	tags, hands, err := parseNotation(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := parseRulesTag(tags[RulesTag])
+	if err != nil {
+		return nil, nil, err
+	}
+	startingPlayer, err := parseTagPlayer(tags[StartingPlayerTag], rules.Table())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(hands) == 0 {
+		return nil, nil, fmt.Errorf("notation: no hand")
+	}
+	decks := make([][]Card, len(hands))
+	for i, hand := range hands {
+		if hand.deck == nil {
+			return nil, nil, fmt.Errorf("notation: line %d: hand %d has no deal", hand.line, hand.number)
+		}
+		decks[i] = hand.deck
+	}
+	if rules.RealisticDealing {
+		// Only the first deck was shuffled, the first cut tells how.
+		first := hands[0]
+		if first.cut == nil {
+			return nil, nil, fmt.Errorf("notation: line %d: hand %d has no cut", first.line, first.number)
+		}
+		_, position, err := parseCut(*first.cut, rules.Table())
+		if err != nil {
+			return nil, nil, fmt.Errorf("notation: line %d: %w", first.cut.number, err)
+		}
+		decks = [][]Card{cutDeck(first.deck, MAX_DECK_SIZE-position)}
+	}
+	gm := NewBeloteGameWithDealer(rules, newReplayDealerFactory(decks))
+	gm.startingPlayer = startingPlayer
+	gm.Start()
+
+	for i, hand := range hands {
+		if err := hand.applyCut(&gm); err != nil {
+			return nil, nil, err
+		}
+		if dealtHands(&gm) != i+1 {
+			return nil, nil, fmt.Errorf("notation: line %d: hand %d starts before the previous one is over", hand.line, hand.number)
+		}
+		if err := hand.apply(&gm); err != nil {
+			return nil, nil, err
+		}
+	}
+	if dealtHands(&gm) > len(hands) {
+		return nil, nil, fmt.Errorf("notation: the deal of hand %d is missing", gm.handNumber)
+	}
+
+	return &gm, tags, nil
+}
+
+// dealtHands counts the deals of gm, redeals included.
+func dealtHands(gm *BeloteGame) int {
+	dealt := 0
+	for _, event := range gm.events {
+		if _, ok := event.(HandDealtEvent); ok {
+			dealt++
+		}
+	}
+	return dealt
+}
+
+// sortedTagNames orders tags like the notation does: date, seed and the
+// players at table first, then the game setup, then any other tag by name.
+func sortedTagNames(tags map[string]string, table Table) []string {
+	order := []string{DateTag, SeedTag}
+	for _, player := range table.PlayerIds() {
+		order = append(order, PlayerTag(player))
+	}
+	order = append(order, StartingPlayerTag, RulesTag)
+
+	var names, others []string
+	for _, name := range order {
+		if _, ok := tags[name]; ok {
+			names = append(names, name)
+		}
+	}
+	for name := range tags {
+		if !slices.Contains(order, name) {
+			others = append(others, name)
+		}
+	}
+	slices.Sort(others)
+	return append(names, others...)
+}
+
+// formatRulesTag names the preset the rules are, or writes them as JSON.
+func formatRulesTag(rules RuleSet) string {
+	for _, name := range rulePresets {
+		if preset, _ := GetRuleSetPreset(name); reflect.DeepEqual(preset, rules) {
+			return name
+		}
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func parseRulesTag(value string) (RuleSet, error) {
+	if value == "" {
+		return DefaultRuleSet(), nil
+	}
+	if rules, ok := GetRuleSetPreset(value); ok {
+		return rules, nil
+	}
+
+	var rules RuleSet
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return RuleSet{}, fmt.Errorf("notation: invalid %s tag: %w", RulesTag, err)
+	}
+	if err := rules.Table().validate(); err != nil {
+		return RuleSet{}, fmt.Errorf("notation: invalid %s tag: %w", RulesTag, err)
+	}
+	return rules, nil
+}
+
+func parseTagPlayer(value string, table Table) (PlayerId, error) {
+	if value == "" {
+		return Player1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < int(Player1) || n > table.Players() {
+		return NoPlayerId, fmt.Errorf("notation: invalid %s tag %q", StartingPlayerTag, value)
+	}
+	return PlayerId(n), nil
+}
+
+func formatCard(card Card) string {
+	return string(card.Rank) + notationSuits[card.Suit]
+}
+
+func parseCard(token string) (Card, error) {
+	if len(token) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", token)
+	}
+	suit, err := parseSuit(token[len(token)-1:])
+	if err != nil {
+		return Card{}, fmt.Errorf("invalid card %q", token)
+	}
+	rank := Rank(token[:len(token)-1])
+	if _, ok := naturalOrderIndex[rank]; !ok {
+		return Card{}, fmt.Errorf("invalid card %q", token)
+	}
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+func parseSuit(token string) (Suit, error) {
+	for suit, letter := range notationSuits {
+		if letter == token {
+			return suit, nil
+		}
+	}
+	return "", fmt.Errorf("invalid suit %q", token)
+}
+
+func formatPlayer(player PlayerId) string {
+	return fmt.Sprintf("P%d", player)
+}
+
+func parsePlayer(token string, table Table) (PlayerId, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(token, "P"))
+	if !strings.HasPrefix(token, "P") || err != nil || n < int(Player1) || n > table.Players() {
+		return NoPlayerId, fmt.Errorf("invalid player %q", token)
+	}
+	return PlayerId(n), nil
+}
+
+// handBelotes returns the cards each player announced Belote with in the
+// last hand of events, in the order they were played.
+func handBelotes(events []GameEvent) map[PlayerId][]Card {
+	belotes := map[PlayerId][]Card{}
+	for _, event := range events {
+		switch e := event.(type) {
+		case HandDealtEvent:
+			clear(belotes)
+		case CardPlayedEvent:
+			if e.Announcement.Belote {
+				belotes[e.Player] = append(belotes[e.Player], e.Card)
+			}
+		}
+	}
+	return belotes
+}
+
+func formatDeclaration(player PlayerId, d Declaration) string {
+	switch v := d.(type) {
+	case PreHandDeclaration:
+		return fmt.Sprintf("%s %s %s", formatPlayer(player), notationDeclarations[v.Type], formatCard(v.HighestCard))
+	case Belote:
+		return fmt.Sprintf("%s %s", formatPlayer(player), beloteNotation)
+	}
+	panic(fmt.Sprintf("unknown declaration %T", d))
+}
+
+// formatDeclarations lists the declarations of hand in seat order, each
+// Belote with the card it was announced with in events.
+func formatDeclarations(hand *Hand, events []GameEvent) []string {
+	belotes := handBelotes(events)
+	var result []string
+	for _, player := range hand.table().PlayerIds() {
+		for _, d := range hand.PlayerDeclarations[player] {
+			item := formatDeclaration(player, d)
+			if _, ok := d.(Belote); ok && len(belotes[player]) > 0 {
+				item += " " + formatCard(belotes[player][0])
+				belotes[player] = belotes[player][1:]
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func formatResult(result HandResult, table Table) string {
+	points := make([]string, 0, len(table.Teams()))
+	for _, team := range table.Teams() {
+		points = append(points, strconv.Itoa(result.Credited[team]))
+	}
+	return strings.Join(points, " ")
+}
+
+// notationWriter writes the hands of a game, replaying its events on the side
+// to find out what the log does not record, such as declarations.
+type notationWriter struct {
+	w   *bufio.Writer
+	err error
+
+	bids, trumps, contras []string
+	recontras, continues  []string
+	trick                 []string
+	trickLeader           PlayerId
+	trickNumber           int
+	cut                   string
+}
+
+func (nw *notationWriter) printf(format string, args ...any) {
+	if nw.err == nil {
+		_, nw.err = fmt.Fprintf(nw.w, format, args...)
+	}
+}
+
+func (nw *notationWriter) writeHands(gm *BeloteGame) error {
+	shadow, err := newReplayGame(gm.events)
+	if err != nil {
+		return fmt.Errorf("notation: %w", err)
+	}
+
+	for i, event := range gm.events {
+		if err := shadow.replayEvent(event); err != nil {
+			return fmt.Errorf("notation: event %d (%s): %w", i, event.Type(), err)
+		}
+
+		switch e := event.(type) {
+		case DeckCutEvent:
+			nw.cut = fmt.Sprintf("%s %d", formatPlayer(e.Player), e.Position)
+		case HandDealtEvent:
+			nw.endHand()
+			nw.printf("\nHand %d\n", e.HandNumber)
+			if nw.cut != "" {
+				nw.printf("Cut: %s\n", nw.cut)
+				nw.cut = ""
+			}
+			switch {
+			case shadow.rules.hasAuction():
+				nw.printf("Deal: %s\n", formatCards(e.Deck))
+			case shadow.rules.dealsInPackets():
+				turned := NUM_CARDS_BEFORE_TRUMP * shadow.rules.Table().Players()
+				nw.printf("Deal: %s | %s | %s\n", formatCards(e.Deck[:turned]), formatCard(e.Deck[turned]), formatCards(e.Deck[turned+1:]))
+			default:
+				nw.printf("Deal: %s | %s\n", formatCard(e.Deck[0]), formatCards(e.Deck[1:]))
+			}
+		case TableTrumpAnsweredEvent:
+			answer := "pass"
+			if e.Accepted {
+				answer = "take"
+			}
+			nw.trumps = append(nw.trumps, formatPlayer(e.Player)+" "+answer)
+		case TrumpSelectedEvent:
+			answer := "pass"
+			if e.Suit != nil {
+				answer = notationSuits[*e.Suit]
+			} else if e.Contract != nil {
+				answer = formatContract(*e.Contract)
+			}
+			nw.trumps = append(nw.trumps, formatPlayer(e.Player)+" "+answer)
+		case BidPlacedEvent:
+			nw.bids = append(nw.bids, formatPlayer(e.Player)+" "+formatBid(e.Bid))
+		case HandRedealtEvent:
+			nw.writeBidding()
+		case ContraAnsweredEvent:
+			if shadow.rules.Mode == BulgarianMode {
+				nw.bids = append(nw.bids, formatPlayer(e.Player)+" "+doubleNotation)
+			} else {
+				nw.contras = append(nw.contras, formatPlayer(e.Player)+" "+formatCall(e.Called))
+			}
+		case RecontraAnsweredEvent:
+			if shadow.rules.Mode == BulgarianMode {
+				nw.bids = append(nw.bids, formatPlayer(e.Player)+" "+redoubleNotation)
+			} else {
+				nw.recontras = append(nw.recontras, formatPlayer(e.Player)+" "+formatCall(e.Called))
+			}
+		case CardPlayedEvent:
+			nw.writeBidding()
+			if len(nw.trick) == 0 {
+				nw.trickLeader = e.Player
+			}
+			nw.trick = append(nw.trick, formatCard(e.Card))
+		case TrickCompletedEvent:
+			nw.writeTrick()
+		case HandCompletedEvent:
+			nw.writeBidding()
+			nw.writeList("Declarations", formatDeclarations(shadow.currentHand, gm.events[:i+1]), ", ")
+			nw.printf("Result: %s\n", formatResult(e.Result, shadow.rules.Table()))
+		case HandSummaryAcknowledgedEvent:
+			nw.continues = append(nw.continues, formatPlayer(e.Player))
+		case HandSummaryEndedEvent:
+			if e.Forced {
+				nw.continues = append(nw.continues, forcedNotation)
+			}
+		}
+	}
+
+	// The hand in progress is written as far as it went.
+	if gm.state == GameInProgress {
+		nw.writeBidding()
+		nw.writeTrick()
+		nw.writeList("Declarations", formatDeclarations(shadow.currentHand, gm.events), ", ")
+	}
+	nw.endHand()
+	return nw.err
+}
+
+func (nw *notationWriter) writeBidding() {
+	nw.writeList("Bids", nw.bids, ", ")
+	nw.writeList("Trump", nw.trumps, ", ")
+	nw.writeList("Contra", nw.contras, ", ")
+	nw.writeList("Recontra", nw.recontras, ", ")
+	nw.bids, nw.trumps, nw.contras, nw.recontras = nil, nil, nil, nil
+}
+
+func (nw *notationWriter) writeTrick() {
+	if len(nw.trick) == 0 {
+		return
+	}
+	nw.trickNumber++
+	nw.printf("%d. %s: %s\n", nw.trickNumber, formatPlayer(nw.trickLeader), strings.Join(nw.trick, " "))
+	nw.trick = nil
+}
+
+func (nw *notationWriter) endHand() {
+	nw.writeList("Continue", nw.continues, " ")
+	nw.continues = nil
+	nw.trickNumber = 0
+}
+
+func (nw *notationWriter) writeList(name string, items []string, sep string) {
+	if len(items) > 0 {
+		nw.printf("%s: %s\n", name, strings.Join(items, sep))
+	}
+}
+
+func formatCards(cards []Card) string {
+	tokens := make([]string, len(cards))
+	for i, card := range cards {
+		tokens[i] = formatCard(card)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// formatContract writes the suit letter of a suit contract, or the name of a
+// no-trumps or all-trumps contract.
+func formatContract(contract Contract) string {
+	if suit, ok := contract.TrumpSuit(); ok {
+		return notationSuits[suit]
+	}
+	return notationContracts[contract.Mode]
+}
+
+func parseContract(token string) (Contract, error) {
+	for mode, name := range notationContracts {
+		if token == name {
+			return Contract{Mode: mode}, nil
+		}
+	}
+	suit, err := parseSuit(token)
+	if err != nil {
+		return Contract{}, fmt.Errorf("invalid contract %q", token)
+	}
+	return TrumpContract(suit), nil
+}
+
+func formatBid(bid *Bid) string {
+	if bid == nil {
+		return "pass"
+	}
+	switch {
+	case bid.Points == 0:
+		return formatContract(bid.Contract)
+	case bid.IsCapot():
+		return capotNotation + " " + formatContract(bid.Contract)
+	}
+	return strconv.Itoa(bid.Points) + " " + formatContract(bid.Contract)
+}
+
+// parseBid reads the fields of a bid after its player. A pass is a nil bid.
+func parseBid(fields []string) (*Bid, error) {
+	switch {
+	case len(fields) == 1 && fields[0] == "pass":
+		return nil, nil
+	case len(fields) == 1:
+		contract, err := parseContract(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		return &Bid{Contract: contract}, nil
+	case len(fields) != 2:
+		return nil, fmt.Errorf("invalid bid %q", strings.Join(fields, " "))
+	}
+
+	points := CAPOT_BID
+	if fields[0] != capotNotation {
+		var err error
+		if points, err = strconv.Atoi(fields[0]); err != nil {
+			return nil, fmt.Errorf("invalid bid points %q", fields[0])
+		}
+	}
+	contract, err := parseContract(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	return &Bid{Points: points, Contract: contract}, nil
+}
+
+func formatCall(called bool) string {
+	if called {
+		return "call"
+	}
+	return "pass"
+}
+
+// notationLine is a line of a hand, kept with its number for error messages.
+type notationLine struct {
+	number int
+	text   string
+}
+
+// handNotation is a hand as read from the notation, before it is played.
+type handNotation struct {
+	line   int
+	number int
+	deck   []Card
+	cut    *notationLine
+
+	bids, trumps, contras []notationLine
+	recontras, tricks     []notationLine
+	declarations          *notationLine
+	result                *notationLine
+	continues             *notationLine
+}
+
+func parseNotation(r io.Reader) (map[string]string, []*handNotation, error) {
+	tags := map[string]string{}
+	var hands []*handNotation
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if err := parseNotationLine(tags, &hands, notationLine{number, text}); err != nil {
+			return nil, nil, fmt.Errorf("notation: line %d: %w", number, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("notation: %w", err)
+	}
+
+	return tags, hands, nil
+}
+
+func parseNotationLine(tags map[string]string, hands *[]*handNotation, line notationLine) error {
+	text := line.text
+	if strings.HasPrefix(text, "[") {
+		if len(*hands) > 0 {
+			return fmt.Errorf("tag after the first hand")
+		}
+		name, value, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(text, "["), "]"), " ")
+		if !ok || !strings.HasSuffix(text, "]") {
+			return fmt.Errorf("invalid tag %q", text)
+		}
+		unquoted, err := strconv.Unquote(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid value of tag %s: %w", name, err)
+		}
+		tags[name] = unquoted
+		return nil
+	}
+
+	if rest, ok := strings.CutPrefix(text, "Hand "); ok {
+		number, err := strconv.Atoi(rest)
+		if err != nil {
+			return fmt.Errorf("invalid hand number %q", rest)
+		}
+		*hands = append(*hands, &handNotation{line: line.number, number: number})
+		return nil
+	}
+
+	if len(*hands) == 0 {
+		return fmt.Errorf("expected a tag or a hand, got %q", text)
+	}
+	hand := (*hands)[len(*hands)-1]
+
+	if number, _, _ := strings.Cut(text, "."); isNumber(number) {
+		hand.tricks = append(hand.tricks, notationLine{line.number, text})
+		return nil
+	}
+
+	name, value, ok := strings.Cut(text, ":")
+	if !ok {
+		return fmt.Errorf("unexpected line %q", text)
+	}
+	value = strings.TrimSpace(value)
+	item := func() *notationLine { return &notationLine{line.number, value} }
+
+	switch name {
+	case "Deal":
+		deck, err := parseDeck(value)
+		if err != nil {
+			return err
+		}
+		hand.deck = deck
+	case "Cut":
+		hand.cut = item()
+	case "Bids":
+		hand.bids = append(hand.bids, splitItems(line.number, value)...)
+	case "Trump":
+		hand.trumps = append(hand.trumps, splitItems(line.number, value)...)
+	case "Contra":
+		hand.contras = append(hand.contras, splitItems(line.number, value)...)
+	case "Recontra":
+		hand.recontras = append(hand.recontras, splitItems(line.number, value)...)
+	case "Declarations":
+		hand.declarations = item()
+	case "Result":
+		hand.result = item()
+	case "Continue":
+		hand.continues = item()
+	default:
+		return fmt.Errorf("unexpected line %q", text)
+	}
+	return nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func parseDeck(value string) ([]Card, error) {
+	var deck []Card
+	for _, token := range strings.Fields(value) {
+		if token == "|" {
+			continue
+		}
+		card, err := parseCard(token)
+		if err != nil {
+			return nil, err
+		}
+		deck = append(deck, card)
+	}
+	if len(deck) != MAX_DECK_SIZE || NewCardSet(deck...).Len() != MAX_DECK_SIZE {
+		return nil, fmt.Errorf("expected a deal of %d different cards", MAX_DECK_SIZE)
+	}
+	return deck, nil
+}
+
+func splitItems(number int, value string) []notationLine {
+	var items []notationLine
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, notationLine{number, item})
+		}
+	}
+	return items
+}
+
+// apply plays the hand on gm and checks what the notation restates.
+func (h *handNotation) apply(gm *BeloteGame) error {
+	if h.number != gm.handNumber {
+		return fmt.Errorf("notation: line %d: expected hand %d, got hand %d", h.line, gm.handNumber, h.number)
+	}
+
+	announcements := map[PlayerId]listedAnnouncement{}
+	if h.declarations != nil {
+		var err error
+		if announcements, err = parseAnnouncements(h.declarations.text, gm.rules.Table()); err != nil {
+			return fmt.Errorf("notation: line %d: %w", h.declarations.number, err)
+		}
+	}
+	applyTrickAnnouncing := func(gm *BeloteGame, line notationLine) error {
+		return applyTrick(gm, line, announcements)
+	}
+
+	steps := []struct {
+		lines []notationLine
+		apply func(*BeloteGame, notationLine) error
+	}{
+		{h.bids, applyBid},
+		{h.trumps, applyTrump},
+		{h.contras, applyContra},
+		{h.recontras, applyRecontra},
+		{h.tricks, applyTrickAnnouncing},
+	}
+	dealt := dealtHands(gm)
+	// A redeal with realistic dealing waits for the cut of the new deal.
+	redealt := func() bool { return dealtHands(gm) != dealt || gm.state == GameCutting }
+	for _, step := range steps {
+		for _, line := range step.lines {
+			if redealt() {
+				return fmt.Errorf("notation: line %d: hand %d was redealt", line.number, h.number)
+			}
+			if err := step.apply(gm, line); err != nil {
+				return fmt.Errorf("notation: line %d: %w", line.number, err)
+			}
+		}
+	}
+	if redealt() {
+		if h.declarations != nil || h.result != nil || h.continues != nil {
+			return fmt.Errorf("notation: line %d: hand %d was redealt", h.line, h.number)
+		}
+		return nil
+	}
+
+	hand := gm.currentHand
+	if h.declarations != nil {
+		if got := strings.Join(formatDeclarations(hand, gm.events), ", "); got != normalizeItems(h.declarations.text) {
+			return fmt.Errorf("notation: line %d: expected declarations %q, the cards give %q", h.declarations.number, h.declarations.text, got)
+		}
+	}
+
+	if h.result != nil {
+		if hand.GetState() != HandFinished {
+			return fmt.Errorf("notation: line %d: hand %d is not finished", h.result.number, h.number)
+		}
+		if got := formatResult(gm.scoreSheet[len(gm.scoreSheet)-1], gm.rules.Table()); got != strings.Join(strings.Fields(h.result.text), " ") {
+			return fmt.Errorf("notation: line %d: expected result %q, the cards give %q", h.result.number, h.result.text, got)
+		}
+	}
+
+	if h.continues != nil {
+		if err := applyContinues(gm, *h.continues); err != nil {
+			return fmt.Errorf("notation: line %d: %w", h.continues.number, err)
+		}
+	}
+	return nil
+}
+
+// applyCut cuts the deck for the hand and checks that the cut gives its deal.
+func (h *handNotation) applyCut(gm *BeloteGame) error {
+	if h.cut == nil {
+		return nil
+	}
+
+	player, position, err := parseCut(*h.cut, gm.rules.Table())
+	if err == nil {
+		err = gm.Cut(player, position)
+	}
+	if err != nil {
+		return fmt.Errorf("notation: line %d: %w", h.cut.number, err)
+	}
+	if !slices.Equal(gm.deck, h.deck) {
+		return fmt.Errorf("notation: line %d: the cut does not give the deal of hand %d", h.cut.number, h.number)
+	}
+	return nil
+}
+
+func parseCut(line notationLine, table Table) (PlayerId, int, error) {
+	player, answer, err := parsePlayerAnswer(line, table)
+	if err != nil {
+		return NoPlayerId, 0, err
+	}
+	position, err := strconv.Atoi(answer)
+	if err != nil || position < MIN_CUT || position > MAX_DECK_SIZE-MIN_CUT {
+		return NoPlayerId, 0, ErrInvalidCut
+	}
+	return player, position, nil
+}
+
+func normalizeItems(value string) string {
+	items := splitItems(0, value)
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = strings.Join(strings.Fields(item.text), " ")
+	}
+	return strings.Join(texts, ", ")
+}
+
+func parsePlayerAnswer(line notationLine, table Table) (PlayerId, string, error) {
+	fields := strings.Fields(line.text)
+	if len(fields) != 2 {
+		return NoPlayerId, "", fmt.Errorf("expected a player and an answer, got %q", line.text)
+	}
+	player, err := parsePlayer(fields[0], table)
+	return player, fields[1], err
+}
+
+func applyTrump(gm *BeloteGame, line notationLine) error {
+	player, answer, err := parsePlayerAnswer(line, gm.rules.Table())
+	if err != nil {
+		return err
+	}
+
+	switch gm.currentHand.GetState() {
+	case TableTrumpSelection:
+		if answer != "take" && answer != "pass" {
+			return fmt.Errorf("expected take or pass on the table trump card, got %q", answer)
+		}
+		return gm.AcceptTableTrump(player, answer == "take")
+	case FreeTrumpSelection:
+		if answer == "pass" {
+			return gm.SelectTrump(player, nil)
+		}
+		contract, err := parseContract(answer)
+		if err != nil {
+			return err
+		}
+		return gm.SelectContract(player, contract)
+	}
+	return fmt.Errorf("trump selection is not in progress")
+}
+
+func applyBid(gm *BeloteGame, line notationLine) error {
+	fields := strings.Fields(line.text)
+	if len(fields) < 2 {
+		return fmt.Errorf("expected a player and a bid, got %q", line.text)
+	}
+	player, err := parsePlayer(fields[0], gm.rules.Table())
+	if err != nil {
+		return err
+	}
+	switch strings.Join(fields[1:], " ") {
+	case doubleNotation:
+		return gm.CallContra(player, true)
+	case redoubleNotation:
+		return gm.CallRecontra(player, true)
+	}
+	bid, err := parseBid(fields[1:])
+	if err != nil {
+		return err
+	}
+	return gm.Bid(player, bid)
+}
+
+func parseCall(answer string) (bool, error) {
+	if answer != "call" && answer != "pass" {
+		return false, fmt.Errorf("expected call or pass, got %q", answer)
+	}
+	return answer == "call", nil
+}
+
+func applyContra(gm *BeloteGame, line notationLine) error {
+	player, answer, err := parsePlayerAnswer(line, gm.rules.Table())
+	if err != nil {
+		return err
+	}
+	call, err := parseCall(answer)
+	if err != nil {
+		return err
+	}
+	return gm.CallContra(player, call)
+}
+
+func applyRecontra(gm *BeloteGame, line notationLine) error {
+	player, answer, err := parsePlayerAnswer(line, gm.rules.Table())
+	if err != nil {
+		return err
+	}
+	call, err := parseCall(answer)
+	if err != nil {
+		return err
+	}
+	return gm.CallRecontra(player, call)
+}
+
+// listedAnnouncement is what a declarations line lists for a player: the
+// pre-hand declarations and the cards Belote was announced with.
+type listedAnnouncement struct {
+	declarations []PreHandDeclaration
+	belotes      CardSet
+}
+
+// parseAnnouncements reads a declarations line into what each player
+// announced over the hand.
+func parseAnnouncements(value string, table Table) (map[PlayerId]listedAnnouncement, error) {
+	announcements := map[PlayerId]listedAnnouncement{}
+	for _, item := range splitItems(0, value) {
+		fields := strings.Fields(item.text)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid declaration %q", item.text)
+		}
+		player, err := parsePlayer(fields[0], table)
+		if err != nil {
+			return nil, err
+		}
+
+		announcement := announcements[player]
+		switch {
+		case len(fields) == 3 && fields[1] == beloteNotation:
+			card, err := parseCard(fields[2])
+			if err != nil {
+				return nil, err
+			}
+			announcement.belotes = announcement.belotes.With(card)
+		case len(fields) == 3:
+			d, err := parseDeclaration(fields[1], fields[2])
+			if err != nil {
+				return nil, err
+			}
+			announcement.declarations = append(announcement.declarations, d)
+		default:
+			return nil, fmt.Errorf("invalid declaration %q", item.text)
+		}
+		announcements[player] = announcement
+	}
+	return announcements, nil
+}
+
+func parseDeclaration(name, highestCard string) (PreHandDeclaration, error) {
+	card, err := parseCard(highestCard)
+	if err != nil {
+		return PreHandDeclaration{}, err
+	}
+	for declType, notation := range notationDeclarations {
+		if notation == name {
+			return PreHandDeclaration{Type: declType, HighestCard: card}, nil
+		}
+	}
+	return PreHandDeclaration{}, fmt.Errorf("invalid declaration %q", name)
+}
+
+// applyTrick plays the cards of a trick, each with what its player is listed
+// as announcing over the hand: the pre-hand declarations with the first card
+// and Belote with the card it is listed with.
+func applyTrick(gm *BeloteGame, line notationLine, announcements map[PlayerId]listedAnnouncement) error {
+	_, rest, _ := strings.Cut(line.text, ".")
+	leaderToken, cards, ok := strings.Cut(rest, ":")
+	if !ok {
+		return fmt.Errorf("expected a trick leader, got %q", line.text)
+	}
+	leader, err := parsePlayer(strings.TrimSpace(leaderToken), gm.rules.Table())
+	if err != nil {
+		return err
+	}
+	if gm.currentHand.CurrentTrick == nil || leader != gm.currentHand.CurrentTrick.StartingPlayer {
+		return fmt.Errorf("expected the trick to be led by %s", formatPlayer(leader))
+	}
+
+	player := leader
+	hand := gm.currentHand
+	for _, token := range strings.Fields(cards) {
+		card, err := parseCard(token)
+		if err != nil {
+			return err
+		}
+
+		announcement := Announcement{Belote: announcements[player].belotes.Contains(card)}
+		if hand.PreviousTrick == nil {
+			announcement.Declarations = announcements[player].declarations
+		}
+
+		if err := gm.PlayCard(player, card, announcement); err != nil {
+			return fmt.Errorf("%s cannot play %s: %w", formatPlayer(player), token, err)
+		}
+		player = gm.rules.Table().Next(player)
+	}
+	return nil
+}
+
+func applyContinues(gm *BeloteGame, line notationLine) error {
+	for _, token := range strings.Fields(line.text) {
+		if token == forcedNotation {
+			if err := gm.EndHandSummary(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		player, err := parsePlayer(token, gm.rules.Table())
+		if err != nil {
+			return err
+		}
+		if err := gm.Continue(player); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// playRandomActions plays up to moves random legal actions, announcing
+// declarations or not at random.
+func playRandomActions(t *testing.T, gm *BeloteGame, seed uint64, moves int) {
+	t.Helper()
+
+	r := rand.New(rand.NewPCG(seed, seed))
+	for ; moves > 0 && gm.GetState() != GameFinished; moves-- {
+		for player := Player1; player <= Player4; player++ {
+			actions := gm.LegalActions(player)
+			if len(actions) == 0 {
+				continue
+			}
+
+			action := actions[r.IntN(len(actions))]
+			var err error
+			if action.Type == PlayCardAction {
+				announcement := gm.GetHand().BestAnnouncement(player, action.Card)
+				if r.IntN(4) == 0 {
+					announcement = Announcement{}
+				}
+				err = gm.PlayCard(player, action.Card, announcement)
+			} else {
+				err = gm.PlayAction(player, action)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+}
+
+func roundTripNotation(t *testing.T, gm *BeloteGame, tags map[string]string) (*BeloteGame, map[string]string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, gm, tags); err != nil {
+		t.Fatal(err)
+	}
+	parsed, parsedTags, err := ParseNotation(&buf)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, buf.String())
+	}
+
+	expected, got := *gm, *parsed
+	expected.dealerFactory, got.dealerFactory = nil, nil
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected the parsed game to be identical to the written one")
+	}
+	return parsed, parsedTags
+}
+
+func TestNotationRoundTripsWholeGames(t *testing.T) {
+	contra, _ := GetRuleSetPreset(ContraRuleSetPreset)
+	for _, rules := range []RuleSet{DefaultRuleSet(), contra} {
+		for seed := uint64(0); seed < 5; seed++ {
+			gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(seed))
+			gm.Start()
+			playRandomActions(t, &gm, seed, 100000)
+			roundTripNotation(t, &gm, nil)
+		}
+	}
+
+	rules, _ := GetRuleSetPreset(StrictRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(9))
+	playGameWithFreeTrumps(t, &gm)
+	roundTripNotation(t, &gm, nil)
+}
+
+func TestNotationRoundTripsUnfinishedGames(t *testing.T) {
+	for _, moves := range []int{0, 3, 20, 40, 80} {
+		gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(3))
+		gm.Start()
+		playRandomActions(t, &gm, 3, moves)
+		roundTripNotation(t, &gm, nil)
+	}
+
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(3))
+	gm.Start()
+	for gm.GetState() != GameHandSummary {
+		playRandomActions(t, &gm, 3, 1)
+	}
+	if err := gm.Continue(Player2); err != nil {
+		t.Fatal(err)
+	}
+	roundTripNotation(t, &gm, nil)
+
+	if err := gm.EndHandSummary(); err != nil {
+		t.Fatal(err)
+	}
+	roundTripNotation(t, &gm, nil)
+}
+
+func TestNotationKeepsTagsAndRules(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.TargetScore = 300
+	rules.CarreRanks = []Rank{Jack, Nine}
+
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(1))
+	gm.Start()
+	playRandomActions(t, &gm, 1, 10)
+
+	tags := map[string]string{
+		DateTag:            "2026.10.18",
+		SeedTag:            "1",
+		PlayerTag(Player1): `Ani "the bold"`,
+		"Event":            "Club night",
+	}
+	parsed, parsedTags := roundTripNotation(t, &gm, tags)
+
+	for name, value := range tags {
+		if parsedTags[name] != value {
+			t.Errorf("expected tag %s to be %q, got %q", name, value, parsedTags[name])
+		}
+	}
+	if !reflect.DeepEqual(parsed.GetRules(), rules) {
+		t.Errorf("expected rules %+v, got %+v", rules, parsed.GetRules())
+	}
+}
+
+func TestWriteNotation(t *testing.T) {
+	rules, _ := GetRuleSetPreset(ContraRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	if err := gm.AcceptTableTrump(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+	passDoubling(t, &gm)
+	if err := gm.PlayCard(Player1, Card{Suit: Spades, Rank: Ten}, gm.GetHand().BestAnnouncement(Player1, Card{Suit: Spades, Rank: Ten})); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, &gm, map[string]string{DateTag: "2026.10.18"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[Date "2026.10.18"]
+[StartingPlayer "1"]
+[Rules "contra"]
+
+Hand 0
+Deal: 7S | 8S 9S 10S JS QS KS AS 7H 8H 9H 10H JH QH KH AH 7D 8D 9D 10D JD QD KD AD 7C 8C 9C 10C JC QC KC AC
+Trump: P1 take
+Contra: P2 pass, P4 pass
+1. P1: 10S
+Declarations: P1 quinte QS
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestParseNotationRejectsInvalidGames(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewSeededDealerFactory(4))
+	gm.Start()
+	for gm.GetState() != GameHandSummary {
+		playRandomActions(t, &gm, 4, 1)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, &gm, nil); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+
+	lines := strings.Split(text, "\n")
+	var resultLine, trickLine string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Result:") {
+			resultLine = line
+		}
+		if strings.HasPrefix(line, "2. ") {
+			trickLine = line
+		}
+	}
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"tampered result", strings.Replace(text, resultLine, "Result: 1 2", 1)},
+		{"missing trick", strings.Replace(text, trickLine, "", 1)},
+		{"unknown card", strings.Replace(text, "Deal: ", "Deal: 1X ", 1)},
+		{"missing deal", strings.Replace(text, "Deal:", "; Deal:", 1)},
+		{"unknown line", text + "Bid: P1 80\n"},
+		{"unknown rules", strings.Replace(text, `"standard"`, `"house"`, 1)},
+	}
+
+	for _, test := range tests {
+		if _, _, err := ParseNotation(strings.NewReader(test.text)); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestNotationRoundTripsRedeals(t *testing.T) {
+	rules, _ := GetRuleSetPreset(RedealRuleSetPreset)
+
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(5))
+	gm.Start()
+	if gm.GetHand().GetState() != TableTrumpSelection {
+		t.Fatalf("expected the table trump card to be offered, got %s", gm.GetHand().GetState())
+	}
+	passTrumps(t, &gm)
+	playRandomActions(t, &gm, 5, 40)
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, &gm, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `[Rules "redeal"]`) {
+		t.Errorf("expected the redeal preset to be named, got\n%s", buf.String())
+	}
+	if strings.Count(buf.String(), "Hand 0\n") != 2 {
+		t.Errorf("expected hand 0 to be written for both deals, got\n%s", buf.String())
+	}
+	roundTripNotation(t, &gm, nil)
+}
+
+func TestNotationRoundTripsFrenchDeals(t *testing.T) {
+	rules, _ := GetRuleSetPreset(FrenchRuleSetPreset)
+	for seed := uint64(0); seed < 5; seed++ {
+		gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(seed))
+		gm.Start()
+		playRandomActions(t, &gm, seed, 500)
+
+		var buf bytes.Buffer
+		if err := WriteNotation(&buf, &gm, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), formatCard(gm.GetHand().GetTableTrump())+" | ") {
+			t.Errorf("expected the turned card to be marked, got\n%s", buf.String())
+		}
+		roundTripNotation(t, &gm, nil)
+	}
+}
+
+func TestNotationRoundTripsCuts(t *testing.T) {
+	rules, _ := GetRuleSetPreset(RealisticRuleSetPreset)
+	coinche, _ := GetRuleSetPreset(CoincheRuleSetPreset)
+	coinche.RealisticDealing, coinche.DealTwoThree = true, true
+	for _, rules := range []RuleSet{rules, coinche} {
+		for seed := uint64(0); seed < 5; seed++ {
+			gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(seed))
+			gm.Start()
+			playRandomActions(t, &gm, seed, 500)
+
+			var buf bytes.Buffer
+			if err := WriteNotation(&buf, &gm, nil); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), "\nCut: ") {
+				t.Errorf("expected the cuts to be written, got\n%s", buf.String())
+			}
+			roundTripNotation(t, &gm, nil)
+
+			if _, err := Replay(gm.GetEvents()); err != nil {
+				t.Errorf("%s, seed %d: %v", rules.Mode, seed, err)
+			}
+
+			// Only the first cut is free, every other one must give its deal.
+			var cuts []string
+			for _, line := range strings.Split(buf.String(), "\n") {
+				if strings.HasPrefix(line, "Cut: ") {
+					cuts = append(cuts, line)
+				}
+			}
+			if len(cuts) < 2 {
+				continue
+			}
+			fields := strings.Fields(cuts[1])
+			position, _ := strconv.Atoi(fields[2])
+			tampered := strings.Replace(buf.String(), cuts[1], fmt.Sprintf("Cut: %s %d", fields[1], position%20+MIN_CUT+1), 1)
+			if _, _, err := ParseNotation(strings.NewReader(tampered)); err == nil {
+				t.Errorf("%s, seed %d: expected a tampered cut to be rejected", rules.Mode, seed)
+			}
+		}
+	}
+}
+
+func TestNotationRoundTripsOneOfTwoBelotes(t *testing.T) {
+	rules, _ := GetRuleSetPreset(CoincheRuleSetPreset)
+	rules.AllTrumpsAllowed = true
+
+	// Player1 holds the King and Queen of Spades and of Hearts.
+	deck := NewDeck()
+	deck[4], deck[13] = deck[13], deck[4]
+	deck[7], deck[14] = deck[14], deck[7]
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(deck))
+	gm.Start()
+	if err := gm.Bid(Player1, &Bid{Points: 80, Contract: AllTrumpsContract}); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range []PlayerId{Player2, Player3, Player4} {
+		if err := gm.Bid(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	passDoubling(t, &gm)
+
+	if err := gm.PlayCard(Player1, Card{Suit: Hearts, Rank: King}, Announcement{Belote: true}); err != nil {
+		t.Fatal(err)
+	}
+	for gm.GetState() == GameInProgress {
+		playFirstValidCard(t, &gm)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, &gm, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Declarations: P1 belote KH\n") {
+		t.Errorf("expected the Belote to be written with its card, got\n%s", buf.String())
+	}
+	roundTripNotation(t, &gm, nil)
+}
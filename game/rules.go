@@ -0,0 +1,253 @@
+package game
+
+import "maps"
+
+// RuleSet holds the house rules a game is played with.
+type RuleSet struct {
+	TargetScore int `json:"targetScore"`
+
+	// Mode is the variant the hands are bid for in. An empty Mode plays
+	// Gurian.
+	Mode GameMode `json:"mode"`
+
+	// Players is how many players sit at the table, 2, 3 or 4. Zero seats
+	// four.
+	Players int `json:"players"`
+
+	DeclarationPoints map[PreHandDeclarationType]int `json:"declarationPoints"`
+	BelotePoints      int                            `json:"belotePoints"`
+
+	// CarreRanks lists the ranks whose carre can be declared.
+	CarreRanks []Rank `json:"carreRanks"`
+
+	// BeloteCountsWithoutDeclarationWin scores Belote for its team even when
+	// the team did not win the declarations of the hand.
+	BeloteCountsWithoutDeclarationWin bool `json:"beloteCountsWithoutDeclarationWin"`
+
+	// LastTrickPoints are awarded to the team taking the last trick. Zero
+	// disables the bonus.
+	LastTrickPoints int `json:"lastTrickPoints"`
+
+	// CapotPoints replace the card points of a team taking every trick of a
+	// hand, last trick bonus included. Zero disables capot scoring.
+	CapotPoints int `json:"capotPoints"`
+
+	// InsideRule gives every point of a hand to the defenders when the
+	// takers score less than them. On a tie the takers' points hang and go
+	// to the winner of the next hand.
+	InsideRule bool `json:"insideRule"`
+
+	// ContraAllowed lets the defenders double the hand once the trump is
+	// chosen, and the takers double it again, before the first card. In
+	// Coinche these are the coinche and the surcoinche, and in Bulgarian the
+	// double and redouble of the auction.
+	ContraAllowed bool `json:"contraAllowed"`
+
+	// TableJackAutoAssigned makes a Jack turned up as the table trump card go
+	// straight to the last player, with its suit as trump. It is a Gurian
+	// rule.
+	TableJackAutoAssigned bool `json:"tableJackAutoAssigned"`
+
+	// RedealWhenAllPass lets the last player pass in the free trump
+	// selection too. When every player passed in both rounds, the cards are
+	// shuffled and dealt again with the same starting player.
+	RedealWhenAllPass bool `json:"redealWhenAllPass"`
+
+	// NoTrumpsAllowed and AllTrumpsAllowed let the free trump selection name
+	// a no-trumps or an all-trumps contract instead of a suit.
+	NoTrumpsAllowed  bool `json:"noTrumpsAllowed"`
+	AllTrumpsAllowed bool `json:"allTrumpsAllowed"`
+
+	// RealisticDealing deals every hand from the cards of the previous one,
+	// collected trick by trick and cut by the player before the dealer, see
+	// BeloteGame.Cut. The cards are dealt in packets, as in French.
+	RealisticDealing bool `json:"realisticDealing"`
+
+	// DealTwoThree deals the first five cards of a deal in packets two then
+	// three at a time, instead of three then two.
+	DealTwoThree bool `json:"dealTwoThree"`
+}
+
+// GameMode is the variant of belote a game is played in. Every mode plays
+// tricks the same way; they differ in how a hand is bid for and scored.
+type GameMode string
+
+const (
+	// GurianMode offers a table trump card, then a free choice of trump.
+	GurianMode GameMode = "Gurian"
+	// CoincheMode deals every card and holds an auction for the contract.
+	// The takers must score the points they bid, see Bid.
+	CoincheMode GameMode = "Coinche"
+	// BulgarianMode deals five cards in packets of three and two, holds an
+	// auction over contracts with doubles and redoubles, then deals three
+	// more in one packet each. Hands are scored on totals rounded to tens.
+	// Declarations follow the contract and are worth their usual points,
+	// which Bulgarian shares with Gurian.
+	BulgarianMode GameMode = "Bulgarian"
+	// FrenchMode bids on the turned card like Gurian, but deals five cards
+	// in packets of three and two before turning it, and the rest in one
+	// packet each once the trump is taken. Takers inside keep their Belote.
+	FrenchMode GameMode = "French"
+)
+
+const (
+	StandardRuleSetPreset = "standard"
+	QuickRuleSetPreset    = "quick"
+	StrictRuleSetPreset   = "strict"
+	// ContraRuleSetPreset plays the standard rules with contra and recontra.
+	ContraRuleSetPreset = "contra"
+	// BonusesRuleSetPreset plays the standard rules with the last trick and
+	// capot bonuses.
+	BonusesRuleSetPreset = "bonuses"
+	// InsideRuleSetPreset plays the standard rules with the inside rule.
+	InsideRuleSetPreset = "inside"
+	// RedealRuleSetPreset plays the standard rules with a new deal when every
+	// player passed on trump.
+	RedealRuleSetPreset = "redeal"
+	// ContractsRuleSetPreset plays the standard rules with no-trumps and
+	// all-trumps contracts in the free trump selection.
+	ContractsRuleSetPreset = "contracts"
+	// TwoPlayerRuleSetPreset and ThreePlayerRuleSetPreset play the standard
+	// rules head-to-head and cutthroat.
+	TwoPlayerRuleSetPreset   = "two-player"
+	ThreePlayerRuleSetPreset = "three-player"
+	CoincheRuleSetPreset     = "coinche"
+	BulgarianRuleSetPreset   = "bulgarian"
+	FrenchRuleSetPreset      = "french"
+	RealisticRuleSetPreset   = "realistic"
+)
+
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		TargetScore:                       TARGET_SCORE,
+		Mode:                              GurianMode,
+		Players:                           NUM_PLAYERS,
+		DeclarationPoints:                 maps.Clone(declarationPoints),
+		BelotePoints:                      Belote{}.Points(),
+		CarreRanks:                        []Rank{Nine, Ten, Jack, Queen, King, Ace},
+		BeloteCountsWithoutDeclarationWin: true,
+		TableJackAutoAssigned:             true,
+	}
+}
+
+// GetRuleSetPreset returns a copy of the named preset.
+func GetRuleSetPreset(name string) (RuleSet, bool) {
+	rules := DefaultRuleSet()
+
+	switch name {
+	case StandardRuleSetPreset:
+	case QuickRuleSetPreset:
+		rules.TargetScore = 500
+	case StrictRuleSetPreset:
+		rules.BeloteCountsWithoutDeclarationWin = false
+		rules.TableJackAutoAssigned = false
+	case ContraRuleSetPreset:
+		rules.ContraAllowed = true
+	case BonusesRuleSetPreset:
+		rules.setBonuses()
+	case InsideRuleSetPreset:
+		rules.InsideRule = true
+	case RedealRuleSetPreset:
+		rules.RedealWhenAllPass = true
+	case ContractsRuleSetPreset:
+		rules.NoTrumpsAllowed = true
+		rules.AllTrumpsAllowed = true
+	case TwoPlayerRuleSetPreset:
+		rules.Players = int(TwoPlayerTable)
+	case ThreePlayerRuleSetPreset:
+		rules.Players = int(ThreePlayerTable)
+	case CoincheRuleSetPreset:
+		rules.Mode = CoincheMode
+		rules.ContraAllowed = true
+		rules.setBonuses()
+	case BulgarianRuleSetPreset:
+		rules.Mode = BulgarianMode
+		rules.ContraAllowed = true
+		rules.setBonuses()
+		rules.InsideRule = true
+		rules.NoTrumpsAllowed = true
+		rules.AllTrumpsAllowed = true
+	case FrenchRuleSetPreset:
+		rules.Mode = FrenchMode
+		rules.TableJackAutoAssigned = false
+		rules.setBonuses()
+		rules.InsideRule = true
+		rules.RedealWhenAllPass = true
+	case RealisticRuleSetPreset:
+		rules.RealisticDealing = true
+	default:
+		return RuleSet{}, false
+	}
+
+	return rules, true
+}
+
+// setBonuses turns on the last trick bonus and capot scoring.
+func (r *RuleSet) setBonuses() {
+	r.LastTrickPoints = 10
+	r.CapotPoints = 250
+}
+
+// Table returns the table the rules seat players at.
+func (r RuleSet) Table() Table {
+	return Table(r.Players)
+}
+
+// hasAuction tells whether hands are bid for in an auction rather than on a
+// table trump card.
+func (r RuleSet) hasAuction() bool {
+	return r.Mode == CoincheMode || r.Mode == BulgarianMode
+}
+
+// dealsInPackets tells whether cards are dealt in packets round the table
+// rather than to one player at a time.
+func (r RuleSet) dealsInPackets() bool {
+	return r.Mode == FrenchMode || r.Mode == BulgarianMode || r.RealisticDealing
+}
+
+// firstPackets returns the packets the first five cards are dealt in.
+func (r RuleSet) firstPackets() []int {
+	if r.DealTwoThree {
+		return []int{2, 3}
+	}
+	return []int{3, 2}
+}
+
+// DeclarationValue returns the points d is worth under these rules.
+func (r *RuleSet) DeclarationValue(d Declaration) int {
+	switch v := d.(type) {
+	case PreHandDeclaration:
+		return r.DeclarationPoints[v.Type]
+	case Belote:
+		return r.BelotePoints
+	}
+	return d.Points()
+}
+
+// FindPreHandDeclarations works like the package level function, but only
+// reports carres of the ranks these rules count.
+func (r *RuleSet) FindPreHandDeclarations(cards []Card) []PreHandDeclaration {
+	var result []PreHandDeclaration
+	result = append(result, findSequences(cards)...)
+	result = append(result, findCarres(cards, r.CarreRanks)...)
+	return result
+}
+
+func (r *RuleSet) allowsContract(contract Contract) bool {
+	switch contract.Mode {
+	case SuitTrumps:
+		_, ok := suitOrderIndex[contract.Suit]
+		return ok
+	case NoTrumps:
+		return r.NoTrumpsAllowed
+	case AllTrumps:
+		return r.AllTrumpsAllowed
+	}
+	return false
+}
+
+func (r RuleSet) clone() RuleSet {
+	r.DeclarationPoints = maps.Clone(r.DeclarationPoints)
+	r.CarreRanks = append([]Rank(nil), r.CarreRanks...)
+	return r
+}
@@ -0,0 +1,179 @@
+package game
+
+import "testing"
+
+func TestRuleSetCarreRanks(t *testing.T) {
+	sevens := []Card{
+		{Suit: Spades, Rank: Seven},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Diamonds, Rank: Seven},
+		{Suit: Clubs, Rank: Seven},
+	}
+
+	rules := DefaultRuleSet()
+	if result := rules.FindPreHandDeclarations(sevens); len(result) != 0 {
+		t.Errorf("expected carre of sevens not to count by default, got %v", result)
+	}
+
+	rules.CarreRanks = append(rules.CarreRanks, Seven)
+	result := rules.FindPreHandDeclarations(sevens)
+	if len(result) != 1 || result[0].Type != Carre {
+		t.Fatalf("expected a carre of sevens, got %v", result)
+	}
+
+	rules.DeclarationPoints[Carre] = 80
+	if points := rules.DeclarationValue(result[0]); points != 80 {
+		t.Errorf("expected carre to be worth 80, got %d", points)
+	}
+}
+
+func TestTableJackNotAutoAssigned(t *testing.T) {
+	deck := NewDeck()
+	deck[0], deck[4] = deck[4], deck[0]
+
+	rules := DefaultRuleSet()
+	rules.TableJackAutoAssigned = false
+
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(deck))
+	gm.Start()
+
+	hand := gm.GetHand()
+	if hand.GetState() != TableTrumpSelection || hand.GetTableTrump() != (Card{Suit: Spades, Rank: Jack}) {
+		t.Errorf("expected the table jack to be offered, got %s with %v", hand.GetState(), hand.GetTableTrump())
+	}
+}
+
+func TestTargetScoreFromRules(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.TargetScore = 1
+
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(8))
+	playGame(t, &gm)
+
+	hands := 0
+	for _, event := range gm.GetEvents() {
+		if event.Type() == HandCompletedEventType {
+			hands++
+		}
+	}
+	if hands != 1 {
+		t.Errorf("expected the game to end after one hand, got %d", hands)
+	}
+}
+
+func TestBeloteWithoutDeclarationWin(t *testing.T) {
+	team2 := Team2
+	newHand := func(countsWithoutWin bool) *Hand {
+		rules := DefaultRuleSet()
+		rules.BeloteCountsWithoutDeclarationWin = countsWithoutWin
+		return &Hand{
+			Totals:            map[TeamId]int{Team1: 0, Team2: 0},
+			BelotePoints:      map[TeamId]int{Team1: 0, Team2: 0},
+			DeclarationWinner: &team2,
+			rules:             rules,
+		}
+	}
+
+	hand := newHand(true)
+	hand.scoreBelote(Player1)
+	if hand.Totals[Team1] != 20 {
+		t.Errorf("expected Belote to count, got %v", hand.Totals)
+	}
+
+	hand = newHand(false)
+	hand.scoreBelote(Player1)
+	hand.scoreBelote(Player2)
+	if hand.Totals[Team1] != 0 || hand.Totals[Team2] != 20 {
+		t.Errorf("expected Belote to count only for the declaration winner, got %v", hand.Totals)
+	}
+}
+
+func TestGetRuleSetPreset(t *testing.T) {
+	quick, ok := GetRuleSetPreset(QuickRuleSetPreset)
+	if !ok || quick.TargetScore >= TARGET_SCORE {
+		t.Errorf("expected a shorter quick preset, got %+v", quick)
+	}
+
+	standard, _ := GetRuleSetPreset(StandardRuleSetPreset)
+	standard.DeclarationPoints[Tierce] = 0
+	if again, _ := GetRuleSetPreset(StandardRuleSetPreset); again.DeclarationPoints[Tierce] != 20 {
+		t.Errorf("expected presets not to share state")
+	}
+
+	if _, ok := GetRuleSetPreset("unknown"); ok {
+		t.Errorf("expected unknown preset to be rejected")
+	}
+}
+
+// passTrumps has every player pass on the table trump card and in the free
+// selection.
+func passTrumps(t *testing.T, gm *BeloteGame) {
+	t.Helper()
+
+	for range NUM_PLAYERS {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.AcceptTableTrump(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range NUM_PLAYERS {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.SelectTrump(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRedealWhenAllPass(t *testing.T) {
+	rules, _ := GetRuleSetPreset(RedealRuleSetPreset)
+
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	first := gm.GetHand()
+	passTrumps(t, &gm)
+
+	hand := gm.GetHand()
+	if hand == first || hand.GetState() == HandRedeal {
+		t.Fatalf("expected a new deal, got a hand in state %s", hand.GetState())
+	}
+	if hand.StartingPlayer != first.StartingPlayer || gm.GetHandNumber() != 0 || gm.GetRedeals() != 1 {
+		t.Errorf("expected hand 0 to be redealt from %d, got hand %d from %d after %d redeals",
+			first.StartingPlayer, gm.GetHandNumber(), hand.StartingPlayer, gm.GetRedeals())
+	}
+
+	events := gm.GetEvents()
+	redealt, ok := events[len(events)-2].(HandRedealtEvent)
+	if !ok || redealt != (HandRedealtEvent{HandNumber: 0, StartingPlayer: first.StartingPlayer}) {
+		t.Errorf("expected a redeal event before the new deal, got %+v", events[len(events)-2])
+	}
+	if dealt, ok := events[len(events)-1].(HandDealtEvent); !ok || dealt.HandNumber != 0 {
+		t.Errorf("expected hand 0 to be dealt again, got %+v", events[len(events)-1])
+	}
+}
+
+func TestLastPlayerMustChooseTrumpWithoutRedeal(t *testing.T) {
+	gm := NewBeloteGameWithDealer(DefaultRuleSet(), NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+	for range NUM_PLAYERS {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.AcceptTableTrump(player, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range NUM_PLAYERS - 1 {
+		player, _ := gm.GetHand().GetCurrentTurn()
+		if err := gm.SelectTrump(player, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	last, _ := gm.GetHand().GetCurrentTurn()
+	if err := gm.SelectTrump(last, nil); err == nil {
+		t.Errorf("expected the last player not to be allowed to pass")
+	}
+	for _, action := range gm.LegalActions(last) {
+		if action.Suit == nil {
+			t.Errorf("expected the last player not to be offered a pass")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import "fmt"
+
+// Table is the number of players seated at a game. Four players play in two
+// partnerships, Player1 and Player3 against Player2 and Player4. Two or three
+// players play cutthroat: every seat is a team of its own, Player1 playing as
+// Team1, Player2 as Team2 and Player3 as Team3.
+//
+// The zero Table seats four, so that rules and tricks written before seat
+// counts existed keep their meaning.
+type Table int
+
+const (
+	TwoPlayerTable   Table = 2
+	ThreePlayerTable Table = 3
+	FourPlayerTable  Table = NUM_PLAYERS
+)
+
+var ErrUnsupportedTable = fmt.Errorf("a game is played by 2, 3 or 4 players")
+
+// Players returns how many players sit at the table.
+func (t Table) Players() int {
+	if t == 0 {
+		return NUM_PLAYERS
+	}
+	return int(t)
+}
+
+func (t Table) validate() error {
+	if t.Players() < int(TwoPlayerTable) || t.Players() > NUM_PLAYERS {
+		return ErrUnsupportedTable
+	}
+	return nil
+}
+
+// HasPartnerships tells whether players sit in teams of two.
+func (t Table) HasPartnerships() bool {
+	return t.Players() == NUM_PLAYERS
+}
+
+// PlayerIds returns the seats of the table in order, from Player1.
+func (t Table) PlayerIds() []PlayerId {
+	players := make([]PlayerId, 0, t.Players())
+	for player := Player1; int(player) <= t.Players(); player++ {
+		players = append(players, player)
+	}
+	return players
+}
+
+// PlayerIdsFrom returns the seats of the table in playing order, from player.
+func (t Table) PlayerIdsFrom(player PlayerId) []PlayerId {
+	players := make([]PlayerId, 0, t.Players())
+	for range t.Players() {
+		players = append(players, player)
+		player = t.Next(player)
+	}
+	return players
+}
+
+// Teams returns the teams playing at the table, in order.
+func (t Table) Teams() []TeamId {
+	if t.HasPartnerships() {
+		return []TeamId{Team1, Team2}
+	}
+	teams := make([]TeamId, 0, t.Players())
+	for _, player := range t.PlayerIds() {
+		teams = append(teams, TeamId(player))
+	}
+	return teams
+}
+
+func (t Table) Team(player PlayerId) TeamId {
+	if t.HasPartnerships() {
+		return player.GetTeam()
+	}
+	return TeamId(player)
+}
+
+// Teammate returns the partner of player, or NoPlayerId when everyone plays
+// alone.
+func (t Table) Teammate(player PlayerId) PlayerId {
+	if t.HasPartnerships() {
+		return player.GetTeammateId()
+	}
+	return NoPlayerId
+}
+
+// Next returns the player seated after player, who plays after them.
+func (t Table) Next(player PlayerId) PlayerId {
+	return player%PlayerId(t.Players()) + 1
+}
+
+// Previous returns the player seated before player.
+func (t Table) Previous(player PlayerId) PlayerId {
+	n := PlayerId(t.Players())
+	return (player-2+n)%n + 1
+}
+
+// talonSize returns how many cards are left face down once every player holds
+// their cards. Two players draw them one each after every trick, the winner
+// first, while three leave them out of the hand.
+func (t Table) talonSize() int {
+	if t.Players() == int(TwoPlayerTable) {
+		return MAX_DECK_SIZE - t.Players()*NUM_CARDS_PER_PLAYER
+	}
+	return 0
+}
+
+// newTeamPoints returns a score of zero for every team at the table.
+func (t Table) newTeamPoints() map[TeamId]int {
+	points := make(map[TeamId]int, len(t.Teams()))
+	for _, team := range t.Teams() {
+		points[team] = 0
+	}
+	return points
+}
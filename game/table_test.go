@@ -0,0 +1,159 @@
+package game
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableSeating(t *testing.T) {
+	testCases := []struct {
+		table    Table
+		next     []PlayerId
+		teams    []TeamId
+		teammate PlayerId
+	}{
+		{TwoPlayerTable, []PlayerId{Player2, Player1}, []TeamId{Team1, Team2}, NoPlayerId},
+		{ThreePlayerTable, []PlayerId{Player2, Player3, Player1}, []TeamId{Team1, Team2, Team3}, NoPlayerId},
+		{FourPlayerTable, []PlayerId{Player2, Player3, Player4, Player1}, []TeamId{Team1, Team2}, Player3},
+		{0, []PlayerId{Player2, Player3, Player4, Player1}, []TeamId{Team1, Team2}, Player3},
+	}
+
+	for _, tc := range testCases {
+		var next []PlayerId
+		for _, player := range tc.table.PlayerIds() {
+			next = append(next, tc.table.Next(player))
+			if previous := tc.table.Previous(tc.table.Next(player)); previous != player {
+				t.Errorf("%d players: expected %d before %d, got %d", tc.table.Players(), player, tc.table.Next(player), previous)
+			}
+		}
+		if !reflect.DeepEqual(next, tc.next) {
+			t.Errorf("%d players: expected turns to go to %v, got %v", tc.table.Players(), tc.next, next)
+		}
+		if teams := tc.table.Teams(); !reflect.DeepEqual(teams, tc.teams) {
+			t.Errorf("%d players: expected teams %v, got %v", tc.table.Players(), tc.teams, teams)
+		}
+		if teammate := tc.table.Teammate(Player1); teammate != tc.teammate {
+			t.Errorf("%d players: expected %d as Player1's teammate, got %d", tc.table.Players(), tc.teammate, teammate)
+		}
+	}
+
+	if team := ThreePlayerTable.Team(Player3); team != Team3 {
+		t.Errorf("expected Player3 to play as Team3, got %d", team)
+	}
+}
+
+func TestTwoPlayerHandDrawsFromTalon(t *testing.T) {
+	rules, _ := GetRuleSetPreset(TwoPlayerRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewFixedDealerFactory(NewDeck()))
+	gm.Start()
+
+	hand := gm.GetHand()
+	if err := gm.AcceptTableTrump(Player1, true); err != nil {
+		t.Fatal(err)
+	}
+	passDoubling(t, &gm)
+	if hand.Talon != 16 || hand.PlayerCards[Player1].Len() != NUM_CARDS_PER_PLAYER || hand.PlayerCards[Player2].Len() != NUM_CARDS_PER_PLAYER {
+		t.Fatalf("expected two hands of 8 cards and a talon of 16, got %d and %d cards and %d in the talon",
+			hand.PlayerCards[Player1].Len(), hand.PlayerCards[Player2].Len(), hand.Talon)
+	}
+
+	playFirstValidCard(t, &gm)
+	playFirstValidCard(t, &gm)
+	if hand.Talon != 14 || hand.PlayerCards[Player1].Len() != NUM_CARDS_PER_PLAYER || hand.PlayerCards[Player2].Len() != NUM_CARDS_PER_PLAYER {
+		t.Errorf("expected both players to draw after the trick, got %d and %d cards and %d in the talon",
+			hand.PlayerCards[Player1].Len(), hand.PlayerCards[Player2].Len(), hand.Talon)
+	}
+
+	playHand(t, &gm)
+	if len(hand.CompletedTricks) != 16 {
+		t.Errorf("expected the whole deck to be played in 16 tricks, got %d", len(hand.CompletedTricks))
+	}
+	if points := hand.TrickPoints[Team1] + hand.TrickPoints[Team2]; points != 152 {
+		t.Errorf("expected every card point to be taken, got %d", points)
+	}
+}
+
+func TestThreePlayerGameHasNoTeams(t *testing.T) {
+	rules, _ := GetRuleSetPreset(ThreePlayerRuleSetPreset)
+	rules.TargetScore = 300
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(6))
+	playGame(t, &gm)
+
+	for _, result := range gm.GetScoreSheet() {
+		if len(result.Totals) != 3 {
+			t.Fatalf("expected a total for each of the three players, got %v", result.Totals)
+		}
+	}
+	if len(gm.GetScores()) != 3 {
+		t.Errorf("expected a score for each of the three players, got %v", gm.GetScores())
+	}
+	if hand := gm.GetHand(); len(hand.CompletedTricks) != NUM_CARDS_PER_PLAYER || len(hand.CompletedTricks[0].Cards) != 3 {
+		t.Errorf("expected 8 tricks of 3 cards, got %d tricks", len(hand.CompletedTricks))
+	}
+}
+
+func TestInsideRuleSharesLostPointsBetweenBestDefenders(t *testing.T) {
+	rules, _ := GetRuleSetPreset(ThreePlayerRuleSetPreset)
+	rules.InsideRule = true
+	hand := &Hand{
+		Taker:     Player3,
+		TakerTeam: Team3,
+		Totals:    map[TeamId]int{Team1: 60, Team2: 60, Team3: 41},
+		rules:     rules,
+	}
+	hand.scoreContract()
+
+	expected := map[TeamId]int{Team1: 81, Team2: 80, Team3: 0}
+	if hand.ContractOutcome != ContractInside || !reflect.DeepEqual(hand.Totals, expected) {
+		t.Errorf("expected %v inside, got %v %s", expected, hand.Totals, hand.ContractOutcome)
+	}
+}
+
+func TestNotationRoundTripsSmallTables(t *testing.T) {
+	for _, preset := range []string{TwoPlayerRuleSetPreset, ThreePlayerRuleSetPreset} {
+		rules, _ := GetRuleSetPreset(preset)
+		gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(8))
+		gm.Start()
+		playRandomActions(t, &gm, 8, 120)
+
+		var buf bytes.Buffer
+		if err := WriteNotation(&buf, &gm, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), `[Rules "`+preset+`"]`) {
+			t.Errorf("expected the %s preset to be written, got\n%s", preset, buf.String())
+		}
+		roundTripNotation(t, &gm, nil)
+
+		tags := map[string]string{PlayerTag(Player1): "Ani", PlayerTag(Player2): "Bo", PlayerTag(Player3): "Cy"}
+		for seed := uint64(0); seed < 3; seed++ {
+			gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(seed))
+			gm.Start()
+			playRandomActions(t, &gm, seed, 100000)
+			if _, parsedTags := roundTripNotation(t, &gm, tags); parsedTags[PlayerTag(Player3)] != "Cy" {
+				t.Errorf("%s: expected the player tags to be kept, got %v", preset, parsedTags)
+			}
+		}
+	}
+}
+
+func TestNotationRejectsSeatsNotAtTheTable(t *testing.T) {
+	rules, _ := GetRuleSetPreset(TwoPlayerRuleSetPreset)
+	gm := NewBeloteGameWithDealer(rules, NewSeededDealerFactory(1))
+	gm.Start()
+	for gm.GetState() != GameHandSummary {
+		playRandomActions(t, &gm, 1, 1)
+	}
+	continueToNextHand(t, &gm)
+
+	var buf bytes.Buffer
+	if err := WriteNotation(&buf, &gm, nil); err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(buf.String(), "Continue: P1", "Continue: P3", 1)
+	if _, _, err := ParseNotation(strings.NewReader(tampered)); err == nil || !strings.Contains(err.Error(), `invalid player "P3"`) {
+		t.Errorf("expected a seat the table does not have to be rejected, got %v", err)
+	}
+}
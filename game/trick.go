@@ -1,11 +1,16 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+)
 
 type Trick struct {
 	StartingPlayer PlayerId
 	Cards          map[PlayerId]Card
-	Trump          Suit
+	Trump          Contract
+	// Table is the table the trick is played at, one card per player.
+	Table Table
 }
 
 type TrickResult struct {
@@ -20,15 +25,18 @@ var (
 	ErrMustPlayHigherRankTrumpCard = fmt.Errorf("player must play a higher rank trump card")
 )
 
-func NewTrick(startingPlayer PlayerId, trump Suit) *Trick {
+func NewTrick(startingPlayer PlayerId, trump Contract, table Table) *Trick {
 	return &Trick{
 		StartingPlayer: startingPlayer,
-		Cards:          make(map[PlayerId]Card, 4),
+		Cards:          make(map[PlayerId]Card, table.Players()),
 		Trump:          trump,
+		Table:          table,
 	}
 }
 
-func (t *Trick) PlayCard(player PlayerId, card Card, playerCards map[Card]bool) error {
+// PlayCard puts card on the trick for player, who holds playerCards. Taking
+// the card out of the player's cards is left to the caller.
+func (t *Trick) PlayCard(player PlayerId, card Card, playerCards CardSet) error {
 	currentPlayer, err := t.GetCurrentTurn()
 	if err != nil {
 		return err
@@ -43,7 +51,6 @@ func (t *Trick) PlayCard(player PlayerId, card Card, playerCards map[Card]bool)
 	}
 
 	t.Cards[player] = card
-	delete(playerCards, card)
 	return nil
 }
 
@@ -53,30 +60,48 @@ func (t *Trick) GetTrickResult() (*TrickResult, error) {
 	}
 
 	total := 0
+	for _, card := range t.Cards {
+		total += t.Trump.CardPoints(card)
+	}
+
+	return &TrickResult{t.getBestCardOwner(), total}, nil
+}
+
+// GetCurrentWinner returns the player whose card currently takes the trick.
+func (t *Trick) GetCurrentWinner() (PlayerId, error) {
+	if len(t.Cards) == 0 {
+		return Player1, fmt.Errorf("no card has been played")
+	}
+
+	return t.getBestCardOwner(), nil
+}
+
+func (t *Trick) Clone() *Trick {
+	clone := *t
+	clone.Cards = maps.Clone(t.Cards)
+	return &clone
+}
+
+func (t *Trick) getBestCardOwner() PlayerId {
 	bestCardOwner := t.StartingPlayer
 
+	trump, hasTrump := t.Trump.TrumpSuit()
 	for player, card := range t.Cards {
-		if card.Suit == t.Trump {
-			total += card.Rank.GetTrumpPoints()
-		} else {
-			total += card.Rank.GetNonTrumpPoints()
-		}
-
 		bestCard := t.Cards[bestCardOwner]
-		if bestCard.Suit == t.Trump {
-			if card.Suit == t.Trump && card.Rank.TrickOrder(true) > bestCard.Rank.TrickOrder(true) {
+		if card.Suit == bestCard.Suit {
+			if t.Trump.TrickOrder(card) > t.Trump.TrickOrder(bestCard) {
 				bestCardOwner = player
 			}
-		} else if card.Suit == t.Trump || (card.Suit == bestCard.Suit && card.Rank.TrickOrder(false) > bestCard.Rank.TrickOrder(false)) {
+		} else if hasTrump && card.Suit == trump {
 			bestCardOwner = player
 		}
 	}
 
-	return &TrickResult{bestCardOwner, total}, nil
+	return bestCardOwner
 }
 
 func (t *Trick) IsFinished() bool {
-	for _, playerId := range []PlayerId{Player1, Player2, Player3, Player4} {
+	for _, playerId := range t.Table.PlayerIds() {
 		if _, ok := t.Cards[playerId]; !ok {
 			return false
 		}
@@ -89,15 +114,43 @@ func (t *Trick) GetCurrentTurn() (PlayerId, error) {
 		return Player1, fmt.Errorf("trick is finished")
 	}
 
-	return (t.StartingPlayer-Player1+PlayerId(len(t.Cards)))%NUM_PLAYERS + Player1, nil
+	return (t.StartingPlayer-Player1+PlayerId(len(t.Cards)))%PlayerId(t.Table.Players()) + Player1, nil
 }
 
 func (t *Trick) GetTableCards() map[PlayerId]Card {
 	return t.Cards
 }
 
-func (t *Trick) validateCard(card Card, playerCards map[Card]bool) error {
-	if owned, ok := playerCards[card]; !ok || !owned {
+// GetLegalCards returns the cards, sorted with CompareCards, that the rules
+// allow to be played from playerCards on this trick.
+func (t *Trick) GetLegalCards(playerCards CardSet) []Card {
+	return t.LegalCardSet(playerCards).Cards()
+}
+
+// LegalCardSet returns the cards of playerCards that the rules allow to be
+// played on this trick. It follows the same rules as validateCard.
+func (t *Trick) LegalCardSet(playerCards CardSet) CardSet {
+	if len(t.Cards) == 0 {
+		return playerCards
+	}
+
+	leadSuit := t.getLeadSuit()
+	if leadCards := playerCards.OfSuit(leadSuit); !leadCards.IsEmpty() {
+		if !t.Trump.IsTrump(leadSuit) {
+			return leadCards
+		}
+		return t.getHigherTrumps(leadCards, leadSuit)
+	}
+	if trump, ok := t.Trump.TrumpSuit(); ok {
+		if trumps := playerCards.OfSuit(trump); !trumps.IsEmpty() {
+			return t.getHigherTrumps(trumps, trump)
+		}
+	}
+	return playerCards
+}
+
+func (t *Trick) validateCard(card Card, playerCards CardSet) error {
+	if !playerCards.Contains(card) {
 		return ErrCardNotOwned
 	}
 
@@ -108,10 +161,11 @@ func (t *Trick) validateCard(card Card, playerCards map[Card]bool) error {
 	leadSuit := t.getLeadSuit()
 	var requiredSuit Suit
 
-	if hasCardOfSuit(playerCards, leadSuit) {
+	trump, hasTrump := t.Trump.TrumpSuit()
+	if playerCards.HasSuit(leadSuit) {
 		requiredSuit = leadSuit
-	} else if hasCardOfSuit(playerCards, t.Trump) {
-		requiredSuit = t.Trump
+	} else if hasTrump && playerCards.HasSuit(trump) {
+		requiredSuit = trump
 	} else {
 		return nil
 	}
@@ -123,7 +177,7 @@ func (t *Trick) validateCard(card Card, playerCards map[Card]bool) error {
 		return ErrMustPlayTrumpCard
 	}
 
-	if requiredSuit == t.Trump {
+	if t.Trump.IsTrump(requiredSuit) {
 		if err := t.validateHigherTrumpRule(card, playerCards); err != nil {
 			return err
 		}
@@ -132,20 +186,22 @@ func (t *Trick) validateCard(card Card, playerCards map[Card]bool) error {
 	return nil
 }
 
-func (t *Trick) validateHigherTrumpRule(card Card, playerCards map[Card]bool) error {
-	playersHighestTrump := getPlayersHighestTrump(playerCards, t.Trump)
+// validateHigherTrumpRule checks that card, a trump, beats the trumps of its
+// suit in the trick when the player can.
+func (t *Trick) validateHigherTrumpRule(card Card, playerCards CardSet) error {
+	playersHighestTrump, ok := playerCards.Highest(card.Suit, true)
 
-	if playersHighestTrump == nil || card.Suit != t.Trump {
+	if !ok {
 		return nil
 	}
 
-	highestTrumpInTrick := t.getHighestTrumpInTrick()
+	highestTrumpInTrick := t.getHighestTrumpInTrick(card.Suit)
 
 	if highestTrumpInTrick == nil {
 		return nil
 	}
 
-	if playersHighestTrump.TrickOrder(true) > highestTrumpInTrick.TrickOrder(true) &&
+	if playersHighestTrump.Rank.TrickOrder(true) > highestTrumpInTrick.TrickOrder(true) &&
 		card.Rank.TrickOrder(true) < highestTrumpInTrick.TrickOrder(true) {
 		return ErrMustPlayHigherRankTrumpCard
 	}
@@ -153,40 +209,35 @@ func (t *Trick) validateHigherTrumpRule(card Card, playerCards map[Card]bool) er
 	return nil
 }
 
-func hasCardOfSuit(playerCards map[Card]bool, suit Suit) bool {
-	for card, owned := range playerCards {
-		if owned && card.Suit == suit {
-			return true
+// getHigherTrumps keeps the trumps of suit beating every card of suit in the
+// trick, unless none of them does.
+func (t *Trick) getHigherTrumps(trumps CardSet, suit Suit) CardSet {
+	highestTrumpInTrick := t.getHighestTrumpInTrick(suit)
+	if highestTrumpInTrick == nil {
+		return trumps
+	}
+
+	var higher CardSet
+	for _, card := range trumps.Cards() {
+		if card.Rank.TrickOrder(true) > highestTrumpInTrick.TrickOrder(true) {
+			higher = higher.With(card)
 		}
 	}
-	return false
+	if higher.IsEmpty() {
+		return trumps
+	}
+	return higher
 }
 
 func (t *Trick) getLeadSuit() Suit {
 	return t.Cards[t.StartingPlayer].Suit
 }
 
-func (t *Trick) getHighestTrumpInTrick() *Rank {
+func (t *Trick) getHighestTrumpInTrick(suit Suit) *Rank {
 	var highestRank *Rank = nil
 
 	for _, card := range t.Cards {
-		if card.Suit != t.Trump {
-			continue
-		}
-
-		if highestRank == nil || card.Rank.TrickOrder(true) > highestRank.TrickOrder(true) {
-			highestRank = &card.Rank
-		}
-	}
-
-	return highestRank
-}
-
-func getPlayersHighestTrump(playerCards map[Card]bool, trump Suit) *Rank {
-	var highestRank *Rank = nil
-
-	for card, owned := range playerCards {
-		if !owned || card.Suit != trump {
+		if card.Suit != suit {
 			continue
 		}
 
@@ -8,7 +8,7 @@ func TestValidateCard(t *testing.T) {
 	testCases := []struct {
 		name          string
 		trick         *Trick
-		playerCards   map[Card]bool
+		playerCards   CardSet
 		cardToPlay    Card
 		expectedError error
 	}{
@@ -17,11 +17,11 @@ func TestValidateCard(t *testing.T) {
 			trick: &Trick{
 				StartingPlayer: Player1,
 				Cards:          make(map[PlayerId]Card),
-				Trump:          Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Spades, Rank: Ace}: true,
+				Trump:          TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Spades, Rank: Ace},
+			),
 			cardToPlay:    Card{Suit: Hearts, Rank: Ace},
 			expectedError: ErrCardNotOwned,
 		},
@@ -30,11 +30,11 @@ func TestValidateCard(t *testing.T) {
 			trick: &Trick{
 				StartingPlayer: Player1,
 				Cards:          make(map[PlayerId]Card),
-				Trump:          Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Clubs, Rank: Ace}: true,
+				Trump:          TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Clubs, Rank: Ace},
+			),
 			cardToPlay:    Card{Suit: Clubs, Rank: Ace},
 			expectedError: nil,
 		},
@@ -46,12 +46,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Clubs, Rank: Seven},
 					Player2: {Suit: Diamonds, Rank: Nine},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Clubs, Rank: Queen}:   true,
-				{Suit: Diamonds, Rank: Jack}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Clubs, Rank: Queen},
+				Card{Suit: Diamonds, Rank: Jack},
+			),
 			cardToPlay:    Card{Suit: Clubs, Rank: Queen},
 			expectedError: nil,
 		},
@@ -63,12 +63,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Hearts, Rank: Seven},
 					Player2: {Suit: Diamonds, Rank: Eight},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Hearts, Rank: Ace}:   true,
-				{Suit: Diamonds, Rank: Ten}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Hearts, Rank: Ace},
+				Card{Suit: Diamonds, Rank: Ten},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Ten},
 			expectedError: ErrMustPlayLeadSuitCard,
 		},
@@ -79,12 +79,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Hearts, Rank: Seven},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Clubs, Rank: Eight}:    true,
-				{Suit: Diamonds, Rank: Eight}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Clubs, Rank: Eight},
+				Card{Suit: Diamonds, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Eight},
 			expectedError: nil,
 		},
@@ -95,12 +95,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Hearts, Rank: Seven},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Clubs, Rank: Eight}:    true,
-				{Suit: Diamonds, Rank: Eight}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Clubs, Rank: Eight},
+				Card{Suit: Diamonds, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Clubs, Rank: Eight},
 			expectedError: ErrMustPlayTrumpCard,
 		},
@@ -111,12 +111,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Clubs, Rank: Ten},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Hearts, Rank: Nine}: true,
-				{Suit: Spades, Rank: Jack}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Hearts, Rank: Nine},
+				Card{Suit: Spades, Rank: Jack},
+			),
 			cardToPlay:    Card{Suit: Hearts, Rank: Nine},
 			expectedError: nil,
 		},
@@ -128,12 +128,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Hearts, Rank: Eight},
 					Player2: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: King}:  true,
-				{Suit: Diamonds, Rank: Eight}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: King},
+				Card{Suit: Diamonds, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: King},
 			expectedError: nil,
 		},
@@ -145,12 +145,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Hearts, Rank: Eight},
 					Player2: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: King}:  true,
-				{Suit: Diamonds, Rank: Eight}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: King},
+				Card{Suit: Diamonds, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Eight},
 			expectedError: ErrMustPlayHigherRankTrumpCard,
 		},
@@ -162,12 +162,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Spades, Rank: Ace},
 					Player2: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Clubs, Rank: Ten}:      true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Clubs, Rank: Ten},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Eight},
 			expectedError: nil,
 		},
@@ -179,12 +179,12 @@ func TestValidateCard(t *testing.T) {
 					Player1: {Suit: Clubs, Rank: Ace},
 					Player2: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Spades, Rank: Ten}:     true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Spades, Rank: Ten},
+			),
 			cardToPlay:    Card{Suit: Spades, Rank: Ten},
 			expectedError: ErrMustPlayTrumpCard,
 		},
@@ -195,12 +195,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Diamonds, Rank: King}:  true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Diamonds, Rank: King},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: King},
 			expectedError: nil,
 		},
@@ -211,12 +211,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Diamonds, Rank: King}:  true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Diamonds, Rank: King},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Eight},
 			expectedError: ErrMustPlayHigherRankTrumpCard,
 		},
@@ -227,12 +227,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Clubs, Rank: Eight}:    true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Clubs, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Diamonds, Rank: Eight},
 			expectedError: nil,
 		},
@@ -243,12 +243,12 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Diamonds, Rank: Eight}: true,
-				{Suit: Clubs, Rank: Eight}:    true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Diamonds, Rank: Eight},
+				Card{Suit: Clubs, Rank: Eight},
+			),
 			cardToPlay:    Card{Suit: Clubs, Rank: Eight},
 			expectedError: ErrMustPlayLeadSuitCard,
 		},
@@ -259,11 +259,11 @@ func TestValidateCard(t *testing.T) {
 				Cards: map[PlayerId]Card{
 					Player1: {Suit: Diamonds, Rank: Queen},
 				},
-				Trump: Diamonds,
-			},
-			playerCards: map[Card]bool{
-				{Suit: Hearts, Rank: Ace}: true,
+				Trump: TrumpContract(Diamonds),
 			},
+			playerCards: NewCardSet(
+				Card{Suit: Hearts, Rank: Ace},
+			),
 			cardToPlay:    Card{Suit: Hearts, Rank: Ace},
 			expectedError: nil,
 		},
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// PlayerView is everything a seat is allowed to know about the current hand:
+// its own cards and what has been shown on the table.
+type PlayerView struct {
+	Player         PlayerId
+	State          HandState
+	StartingPlayer PlayerId
+	Cards          []Card
+
+	TableTrumpCard Card
+	Bids           []Bid
+	Talon          int
+	Trump          Contract
+	Taker          PlayerId
+	Multiplier     int
+	Totals         map[TeamId]int
+	Rules          RuleSet
+
+	CurrentTrick    *Trick
+	PreviousTrick   *Trick
+	CompletedTricks []*Trick
+
+	Declarations      map[PlayerId][]Declaration
+	DeclarationWinner *TeamId
+	RevealedCards     map[PlayerId]CardSet
+
+	LegalActions []Action
+}
+
+// GetPlayerView returns what player can see of the hand. The view shares no
+// state with the hand.
+func (h *Hand) GetPlayerView(player PlayerId) PlayerView {
+	view := PlayerView{
+		Player:            player,
+		State:             h.State,
+		StartingPlayer:    h.StartingPlayer,
+		Cards:             h.PlayerCards[player].Cards(),
+		TableTrumpCard:    h.TableTrumpCard,
+		Bids:              slices.Clone(h.Bids),
+		Talon:             h.Talon,
+		Trump:             h.Trump,
+		Taker:             h.Taker,
+		Multiplier:        h.Multiplier,
+		Totals:            maps.Clone(h.Totals),
+		Rules:             h.rules.clone(),
+		CurrentTrick:      cloneTrick(h.CurrentTrick),
+		PreviousTrick:     cloneTrick(h.PreviousTrick),
+		CompletedTricks:   make([]*Trick, 0, len(h.CompletedTricks)),
+		Declarations:      make(map[PlayerId][]Declaration, len(h.PlayerDeclarations)),
+		DeclarationWinner: h.DeclarationWinner,
+		RevealedCards:     maps.Clone(h.RevealedCards),
+		LegalActions:      h.LegalActions(player),
+	}
+
+	for _, trick := range h.CompletedTricks {
+		view.CompletedTricks = append(view.CompletedTricks, trick.Clone())
+	}
+	for p, decls := range h.PlayerDeclarations {
+		view.Declarations[p] = slices.Clone(decls)
+	}
+
+	return view
+}
+
+// GetPlayerView returns what player can see of the current hand, with the
+// actions the game currently allows them.
+func (gm *BeloteGame) GetPlayerView(player PlayerId) PlayerView {
+	var view PlayerView
+	if gm.currentHand != nil {
+		view = gm.currentHand.GetPlayerView(player)
+	}
+	view.Player = player
+	view.Rules = gm.rules.clone()
+	view.LegalActions = gm.LegalActions(player)
+	return view
+}
+
+// NewHandFromView rebuilds the hand seen in view, with the other players
+// holding no cards. Searching bots deal the cards they cannot see to its
+// clones and play them out. Only a hand in progress with nothing left to
+// draw can be rebuilt, and its score breakdown is not restored beyond the
+// trick points and totals.
+func NewHandFromView(view PlayerView) (*Hand, error) {
+	if view.State != HandInProgress || view.CurrentTrick == nil {
+		return nil, fmt.Errorf("cannot rebuild a hand in state %s", view.State)
+	}
+	if view.Talon > 0 {
+		return nil, fmt.Errorf("cannot rebuild a hand with %d cards left in the talon", view.Talon)
+	}
+
+	table := view.Rules.Table()
+	hand := &Hand{
+		State:                     HandInProgress,
+		CurrentTrick:              view.CurrentTrick.Clone(),
+		CompletedTricks:           make([]*Trick, 0, len(view.CompletedTricks)),
+		StartingPlayer:            view.StartingPlayer,
+		Totals:                    maps.Clone(view.Totals),
+		PlayerCards:               makePlayerCards(table),
+		InitialCards:              map[PlayerId][]Card{},
+		TableTrumpCard:            view.TableTrumpCard,
+		Bids:                      slices.Clone(view.Bids),
+		TableTrumpSelectionStatus: map[PlayerId]bool{},
+		FreeTrumpSelectionStatus:  map[PlayerId]bool{},
+		PlayerDeclarations:        make(map[PlayerId][]Declaration, len(view.Declarations)),
+		DeclarationWinner:         view.DeclarationWinner,
+		RevealedCards:             maps.Clone(view.RevealedCards),
+		TrickPoints:               table.newTeamPoints(),
+		DeclarationPoints:         table.newTeamPoints(),
+		BelotePoints:              table.newTeamPoints(),
+		Taker:                     view.Taker,
+		TakerTeam:                 NoTeamId,
+		ContractOutcome:           ContractPending,
+		ContraSelectionStatus:     map[PlayerId]bool{},
+		RecontraSelectionStatus:   map[PlayerId]bool{},
+		Multiplier:                view.Multiplier,
+		Trump:                     view.Trump,
+		rules:                     view.Rules.clone(),
+	}
+
+	if view.Taker != NoPlayerId {
+		hand.TakerTeam = table.Team(view.Taker)
+	}
+	hand.PlayerCards[view.Player] = NewCardSet(view.Cards...)
+	for player, decls := range view.Declarations {
+		hand.PlayerDeclarations[player] = slices.Clone(decls)
+	}
+	for _, trick := range view.CompletedTricks {
+		result, err := trick.GetTrickResult()
+		if err != nil {
+			return nil, err
+		}
+		hand.CompletedTricks = append(hand.CompletedTricks, trick.Clone())
+		hand.TrickPoints[table.Team(result.WinnerPlayer)] += result.Points
+	}
+	if len(hand.CompletedTricks) > 0 {
+		hand.PreviousTrick = hand.CompletedTricks[len(hand.CompletedTricks)-1]
+	}
+
+	return hand, nil
+}
+
+func cloneTrick(trick *Trick) *Trick {
+	if trick == nil {
+		return nil
+	}
+	return trick.Clone()
+}
@@ -50,6 +50,63 @@ func (d *FreeTrumpSelectionHandDump) GetStartingPlayer() game.PlayerId {
 	return d.StartingPlayer
 }
 
+// BiddingHandDump is a Coinche or Bulgarian hand during its auction. The
+// multiplier tells whether the last bid was doubled or redoubled.
+type BiddingHandDump struct {
+	State          game.HandState `json:"state"`
+	Bids           []game.Bid     `json:"bids"`
+	Passes         int            `json:"passes"`
+	Multiplier     int            `json:"multiplier"`
+	StartingPlayer game.PlayerId  `json:"startingPlayer"`
+}
+
+func (d *BiddingHandDump) GetState() game.HandState {
+	return d.State
+}
+
+func (d *BiddingHandDump) GetStartingPlayer() game.PlayerId {
+	return d.StartingPlayer
+}
+
+type ContraSelectionHandDump struct {
+	State           game.HandState         `json:"state"`
+	Trump           game.Contract          `json:"trump"`
+	Taker           game.PlayerId          `json:"taker"`
+	Multiplier      int                    `json:"multiplier"`
+	SelectionStatus map[game.PlayerId]bool `json:"selectionStatus"`
+	StartingPlayer  game.PlayerId          `json:"startingPlayer"`
+}
+
+func (d *ContraSelectionHandDump) GetState() game.HandState {
+	return d.State
+}
+
+func (d *ContraSelectionHandDump) GetStartingPlayer() game.PlayerId {
+	return d.StartingPlayer
+}
+
+type HandSummaryDump struct {
+	State              game.HandState                      `json:"state"`
+	StartingPlayer     game.PlayerId                       `json:"startingPlayer"`
+	Trump              game.Contract                       `json:"trump"`
+	Taker              game.PlayerId                       `json:"taker"`
+	LastTrick          *TrickDump                          `json:"lastTrick,omitempty"`
+	InitialCards       map[game.PlayerId][]game.Card       `json:"initialCards"`
+	PlayerDeclarations map[game.PlayerId][]DeclarationDump `json:"playerDeclarations"`
+	DeclarationWinner  *game.TeamId                        `json:"declarationWinner,omitempty"`
+	RevealedCards      map[game.PlayerId][]game.Card       `json:"revealedCards,omitempty"`
+	Result             *game.HandResult                    `json:"result,omitempty"`
+	Acknowledged       map[game.PlayerId]bool              `json:"acknowledged"`
+}
+
+func (d *HandSummaryDump) GetState() game.HandState {
+	return d.State
+}
+
+func (d *HandSummaryDump) GetStartingPlayer() game.PlayerId {
+	return d.StartingPlayer
+}
+
 type DeclarationDump struct {
 	Type        string     `json:"type"`
 	HighestCard *game.Card `json:"highestCard,omitempty"`
@@ -58,12 +115,17 @@ type DeclarationDump struct {
 
 type InProgressHandDump struct {
 	State              game.HandState                      `json:"state"`
-	Trump              game.Suit                           `json:"trump"`
+	Trump              game.Contract                       `json:"trump"`
+	Taker              game.PlayerId                       `json:"taker"`
+	Multiplier         int                                 `json:"multiplier"`
 	Trick              TrickDump                           `json:"trick"`
 	PreviousTrick      *TrickDump                          `json:"previousTrick,omitempty"`
 	Totals             map[game.TeamId]int                 `json:"totals"`
 	PlayerDeclarations map[game.PlayerId][]DeclarationDump `json:"playerDeclarations"`
 	DeclarationWinner  *game.TeamId                        `json:"declarationWinner,omitempty"`
+	RevealedCards      map[game.PlayerId][]game.Card       `json:"revealedCards,omitempty"`
+	// Talon counts the cards two players have left to draw.
+	Talon int `json:"talon,omitempty"`
 }
 
 func (d *InProgressHandDump) GetState() game.HandState {
@@ -75,19 +137,29 @@ func (d *InProgressHandDump) GetStartingPlayer() game.PlayerId {
 }
 
 type StateDump struct {
-	RoomId    string                   `json:"roomId"`
-	Players   map[game.PlayerId]string `json:"players"`
-	Teams     map[game.TeamId][]string `json:"teams"`
-	Hand      HandDump                 `json:"hand"`
-	GameState game.GameState           `json:"gameState"`
-	Scores    map[game.TeamId]int      `json:"scores"`
+	RoomId        string                   `json:"roomId"`
+	Players       map[game.PlayerId]string `json:"players"`
+	Teams         map[game.TeamId][]string `json:"teams"`
+	Hand          HandDump                 `json:"hand"`
+	GameState     game.GameState           `json:"gameState"`
+	Scores        map[game.TeamId]int      `json:"scores"`
+	Rules         game.RuleSet             `json:"rules"`
+	HangingPoints int                      `json:"hangingPoints"`
+	ScoreSheet    []game.HandResult        `json:"scoreSheet"`
+	// Redeals counts the times the current hand was dealt again because every
+	// player passed on trump, and Redeal is the last of them.
+	Redeals int                    `json:"redeals"`
+	Redeal  *game.HandRedealtEvent `json:"redeal,omitempty"`
+	// Cutter is the player to cut the deck while the game waits for a cut.
+	Cutter game.PlayerId `json:"cutter,omitempty"`
 }
 
 type UserStateDump struct {
-	GameState StateDump     `json:"gameState"`
-	UserId    string        `json:"userId"`
-	PlayerId  game.PlayerId `json:"playerId"`
-	UserCards []game.Card   `json:"userCards"`
+	GameState    StateDump     `json:"gameState"`
+	UserId       string        `json:"userId"`
+	PlayerId     game.PlayerId `json:"playerId"`
+	UserCards    []game.Card   `json:"userCards"`
+	LegalActions []game.Action `json:"legalActions"`
 }
 
 var (
@@ -96,12 +168,18 @@ var (
 
 func (r *Room) DumpState() StateDump {
 	return StateDump{
-		RoomId:    r.Id,
-		Players:   r.dumpPlayersMap(),
-		Teams:     r.dumpTeams(),
-		Hand:      r.dumpHand(),
-		GameState: r.dumpGameState(),
-		Scores:    r.dumpScore(),
+		RoomId:        r.Id,
+		Players:       r.dumpPlayersMap(),
+		Teams:         r.dumpTeams(),
+		Hand:          r.dumpHand(),
+		GameState:     r.dumpGameState(),
+		Scores:        r.dumpScore(),
+		Rules:         r.Game.GetRules(),
+		HangingPoints: r.Game.GetHangingPoints(),
+		ScoreSheet:    r.Game.GetScoreSheet(),
+		Redeals:       r.Game.GetRedeals(),
+		Redeal:        r.dumpRedeal(),
+		Cutter:        r.dumpCutter(),
 	}
 }
 
@@ -115,13 +193,18 @@ func (r *Room) DumpUserState(userId string) (UserStateDump, error) {
 	userCards := r.dumpUserCards(userId)
 
 	return UserStateDump{
-		GameState: state,
-		UserId:    userId,
-		PlayerId:  r.Users[userId].playerId,
-		UserCards: userCards,
+		GameState:    state,
+		UserId:       userId,
+		PlayerId:     r.Users[userId].playerId,
+		UserCards:    userCards,
+		LegalActions: r.dumpLegalActions(userId),
 	}, nil
 }
 
+func (r *Room) dumpLegalActions(userId string) []game.Action {
+	return r.Game.LegalActions(r.Users[userId].playerId)
+}
+
 func (r *Room) dumpUserCards(userId string) []game.Card {
 	user, ok := r.Users[userId]
 	if !ok {
@@ -133,15 +216,7 @@ func (r *Room) dumpUserCards(userId string) []game.Card {
 		return nil
 	}
 
-	cards := hand.GetPlayerCards(user.playerId)
-	result := make([]game.Card, 0, len(cards))
-	for card, exists := range cards {
-		if exists {
-			result = append(result, card)
-		}
-	}
-
-	return result
+	return hand.GetPlayerCards(user.playerId).Cards()
 }
 
 func (r *Room) dumpPlayersMap() map[game.PlayerId]string {
@@ -172,22 +247,74 @@ func (r *Room) dumpHand() HandDump {
 	}
 
 	switch hand.GetState() {
+	case game.Bidding:
+		return dumpBiddingHand(hand)
 	case game.TableTrumpSelection:
 		return dumpTableTrumpSelectionHand(hand)
 	case game.FreeTrumpSelection:
 		return dumpFreeTrumpSelectionHand(hand)
+	case game.ContraSelection:
+		return dumpContraSelectionHand(hand, hand.ContraSelectionStatus)
+	case game.RecontraSelection:
+		return dumpContraSelectionHand(hand, hand.RecontraSelectionStatus)
 	case game.HandInProgress:
-		return dumpInProgressHand(hand)
+		return dumpInProgressHand(hand, r.Game.GetRules())
 	case game.HandFinished:
-		return nil
+		return r.dumpHandSummary(hand)
 	}
 	return nil
 }
 
+func (r *Room) dumpHandSummary(hand *game.Hand) *HandSummaryDump {
+	var lastTrick *TrickDump
+	if len(hand.CompletedTricks) > 0 {
+		lastTrick = dumpTrick(hand.CompletedTricks[len(hand.CompletedTricks)-1])
+	}
+
+	var result *game.HandResult
+	if scoreSheet := r.Game.GetScoreSheet(); len(scoreSheet) > 0 {
+		result = &scoreSheet[len(scoreSheet)-1]
+	}
+
+	return &HandSummaryDump{
+		State:              hand.GetState(),
+		StartingPlayer:     hand.StartingPlayer,
+		Trump:              hand.GetTrump(),
+		Taker:              hand.Taker,
+		LastTrick:          lastTrick,
+		InitialCards:       hand.InitialCards,
+		PlayerDeclarations: dumpPlayerDeclarations(hand.PlayerDeclarations, r.Game.GetRules()),
+		DeclarationWinner:  hand.DeclarationWinner,
+		RevealedCards:      dumpRevealedCards(hand.RevealedCards),
+		Result:             result,
+		Acknowledged:       r.Game.GetAcknowledged(),
+	}
+}
+
 func (r *Room) dumpGameState() game.GameState {
 	return r.Game.GetState()
 }
 
+func (r *Room) dumpCutter() game.PlayerId {
+	if r.Game.GetState() != game.GameCutting {
+		return game.NoPlayerId
+	}
+	return r.Game.GetCuttingPlayer()
+}
+
+func (r *Room) dumpRedeal() *game.HandRedealtEvent {
+	if r.Game.GetRedeals() == 0 {
+		return nil
+	}
+	events := r.Game.GetEvents()
+	for i := len(events) - 1; i >= 0; i-- {
+		if redeal, ok := events[i].(game.HandRedealtEvent); ok {
+			return &redeal
+		}
+	}
+	return nil
+}
+
 func (r *Room) dumpHandState() game.HandState {
 	if r.Game.GetHand() == nil {
 		return game.HandFinished
@@ -203,6 +330,16 @@ func (r *Room) dumpScore() map[game.TeamId]int {
 	return scores
 }
 
+func dumpBiddingHand(hand *game.Hand) *BiddingHandDump {
+	return &BiddingHandDump{
+		State:          hand.GetState(),
+		Bids:           hand.Bids,
+		Passes:         hand.Passes,
+		Multiplier:     hand.Multiplier,
+		StartingPlayer: hand.StartingPlayer,
+	}
+}
+
 func dumpTableTrumpSelectionHand(hand *game.Hand) *TableTrumpSelectionHandDump {
 	return &TableTrumpSelectionHandDump{
 		State:           hand.GetState(),
@@ -223,7 +360,18 @@ func dumpFreeTrumpSelectionHand(hand *game.Hand) *FreeTrumpSelectionHandDump {
 	}
 }
 
-func dumpInProgressHand(hand *game.Hand) *InProgressHandDump {
+func dumpContraSelectionHand(hand *game.Hand, selectionStatus map[game.PlayerId]bool) *ContraSelectionHandDump {
+	return &ContraSelectionHandDump{
+		State:           hand.GetState(),
+		Trump:           hand.GetTrump(),
+		Taker:           hand.Taker,
+		Multiplier:      hand.Multiplier,
+		SelectionStatus: selectionStatus,
+		StartingPlayer:  hand.StartingPlayer,
+	}
+}
+
+func dumpInProgressHand(hand *game.Hand, rules game.RuleSet) *InProgressHandDump {
 	trick := hand.GetTrick()
 	if trick == nil {
 		return nil
@@ -232,26 +380,39 @@ func dumpInProgressHand(hand *game.Hand) *InProgressHandDump {
 	return &InProgressHandDump{
 		State:              hand.GetState(),
 		Trump:              hand.GetTrump(),
+		Taker:              hand.Taker,
+		Multiplier:         hand.Multiplier,
 		Trick:              *dumpTrick(trick),
 		PreviousTrick:      dumpTrick(hand.PreviousTrick),
 		Totals:             hand.Totals,
-		PlayerDeclarations: dumpPlayerDeclarations(hand.PlayerDeclarations),
+		PlayerDeclarations: dumpPlayerDeclarations(hand.PlayerDeclarations, rules),
 		DeclarationWinner:  hand.DeclarationWinner,
+		RevealedCards:      dumpRevealedCards(hand.RevealedCards),
+		Talon:              hand.Talon,
 	}
 }
 
-func dumpPlayerDeclarations(playerDeclarations map[game.PlayerId][]game.Declaration) map[game.PlayerId][]DeclarationDump {
+func dumpPlayerDeclarations(playerDeclarations map[game.PlayerId][]game.Declaration, rules game.RuleSet) map[game.PlayerId][]DeclarationDump {
 	result := make(map[game.PlayerId][]DeclarationDump, len(playerDeclarations))
 	for player, decls := range playerDeclarations {
 		dumps := make([]DeclarationDump, 0, len(decls))
 		for _, d := range decls {
-			dumps = append(dumps, dumpDeclaration(d))
+			dumps = append(dumps, dumpDeclaration(d, rules))
 		}
 		result[player] = dumps
 	}
 	return result
 }
 
+// dumpRevealedCards lists the declaration cards every player may see.
+func dumpRevealedCards(revealedCards map[game.PlayerId]game.CardSet) map[game.PlayerId][]game.Card {
+	result := make(map[game.PlayerId][]game.Card, len(revealedCards))
+	for player, cards := range revealedCards {
+		result[player] = cards.Cards()
+	}
+	return result
+}
+
 var preHandDeclarationTypeNames = map[game.PreHandDeclarationType]string{
 	game.Tierce:     "Tierce",
 	game.Quarte:     "Quarte",
@@ -261,22 +422,22 @@ var preHandDeclarationTypeNames = map[game.PreHandDeclarationType]string{
 	game.JacksCarre: "JacksCarre",
 }
 
-func dumpDeclaration(d game.Declaration) DeclarationDump {
+func dumpDeclaration(d game.Declaration, rules game.RuleSet) DeclarationDump {
 	switch v := d.(type) {
 	case game.PreHandDeclaration:
 		card := v.HighestCard
 		return DeclarationDump{
 			Type:        preHandDeclarationTypeNames[v.Type],
 			HighestCard: &card,
-			Points:      v.Points(),
+			Points:      rules.DeclarationValue(v),
 		}
 	case game.Belote:
 		return DeclarationDump{
 			Type:   "Belote",
-			Points: v.Points(),
+			Points: rules.DeclarationValue(v),
 		}
 	default:
-		return DeclarationDump{Type: "Unknown", Points: d.Points()}
+		return DeclarationDump{Type: "Unknown", Points: rules.DeclarationValue(d)}
 	}
 }
 
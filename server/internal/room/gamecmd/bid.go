@@ -0,0 +1,30 @@
+package gamecmd
+
+import (
+	"encoding/json"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// BidCommand bids in the Coinche auction, or passes when Bid is nil. The
+// coinche itself is a contra command.
+type BidCommand struct {
+	Bid *game.Bid
+}
+
+const BidCmdType = "bid"
+
+func (c *BidCommand) PlayTurnAs(playerId game.PlayerId, gm *game.BeloteGame) error {
+	return gm.Bid(playerId, c.Bid)
+}
+
+func newBidCommand(cmdBytes []byte) (*BidCommand, error) {
+	bidCmd := &BidCommand{}
+
+	err := json.Unmarshal(cmdBytes, bidCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return bidCmd, nil
+}
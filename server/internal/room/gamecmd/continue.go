@@ -0,0 +1,17 @@
+package gamecmd
+
+import (
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+type ContinueCommand struct{}
+
+const ContinueCmdType = "continue"
+
+func (c *ContinueCommand) PlayTurnAs(playerId game.PlayerId, game *game.BeloteGame) error {
+	return game.Continue(playerId)
+}
+
+func newContinueCommand(cmdBytes []byte) (*ContinueCommand, error) {
+	return &ContinueCommand{}, nil
+}
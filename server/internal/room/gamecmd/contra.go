@@ -0,0 +1,28 @@
+package gamecmd
+
+import (
+	"encoding/json"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+type ContraCommand struct {
+	Called bool
+}
+
+const ContraCmdType = "contra"
+
+func (c *ContraCommand) PlayTurnAs(playerId game.PlayerId, game *game.BeloteGame) error {
+	return game.CallContra(playerId, c.Called)
+}
+
+func newContraCommand(cmdBytes []byte) (*ContraCommand, error) {
+	contraCmd := &ContraCommand{}
+
+	err := json.Unmarshal(cmdBytes, contraCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return contraCmd, nil
+}
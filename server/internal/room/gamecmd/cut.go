@@ -0,0 +1,30 @@
+package gamecmd
+
+import (
+	"encoding/json"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+// CutCommand cuts the deck of the next hand with realistic dealing, lifting
+// Position cards.
+type CutCommand struct {
+	Position int
+}
+
+const CutCmdType = "cut"
+
+func (c *CutCommand) PlayTurnAs(playerId game.PlayerId, gm *game.BeloteGame) error {
+	return gm.Cut(playerId, c.Position)
+}
+
+func newCutCommand(cmdBytes []byte) (*CutCommand, error) {
+	cutCmd := &CutCommand{}
+
+	err := json.Unmarshal(cmdBytes, cutCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return cutCmd, nil
+}
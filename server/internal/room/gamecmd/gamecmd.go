@@ -34,8 +34,18 @@ func NewGameCmdFromJson(data json.RawMessage) (PlayableCmd, error) {
 		return newAcceptTrumpCommandFromJson(data)
 	case SelectTrumpCmdType:
 		return newSelectTrumpCommand(data)
+	case BidCmdType:
+		return newBidCommand(data)
+	case ContraCmdType:
+		return newContraCommand(data)
+	case RecontraCmdType:
+		return newRecontraCommand(data)
 	case PlayCardCmdType:
 		return newPlayCardCommand(data)
+	case ContinueCmdType:
+		return newContinueCommand(data)
+	case CutCmdType:
+		return newCutCommand(data)
 	}
 	return nil, ErrInvalidCmdType
 }
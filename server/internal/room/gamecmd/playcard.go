@@ -6,9 +6,13 @@ import (
 	"github.com/los-dogos-studio/gurian-belote/game"
 )
 
+// PlayCardCommand plays a card. Announcement, when set, lists what the player
+// announces with it; otherwise the player announces everything they can, or
+// nothing with SkipDeclarations.
 type PlayCardCommand struct {
 	Card             game.Card
 	SkipDeclarations bool
+	Announcement     *game.Announcement
 }
 
 const PlayCardCmdType = "playCard"
@@ -24,6 +28,13 @@ func newPlayCardCommand(cmdBytes []byte) (*PlayCardCommand, error) {
 	return playCardCmd, nil
 }
 
-func (c *PlayCardCommand) PlayTurnAs(playerId game.PlayerId, game *game.BeloteGame) error {
-	return game.PlayCard(playerId, c.Card, c.SkipDeclarations)
+func (c *PlayCardCommand) PlayTurnAs(playerId game.PlayerId, gm *game.BeloteGame) error {
+	var announcement game.Announcement
+	switch {
+	case c.Announcement != nil:
+		announcement = *c.Announcement
+	case !c.SkipDeclarations && gm.GetHand() != nil:
+		announcement = gm.GetHand().BestAnnouncement(playerId, c.Card)
+	}
+	return gm.PlayCard(playerId, c.Card, announcement)
 }
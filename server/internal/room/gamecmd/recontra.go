@@ -0,0 +1,28 @@
+package gamecmd
+
+import (
+	"encoding/json"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+)
+
+type RecontraCommand struct {
+	Called bool
+}
+
+const RecontraCmdType = "recontra"
+
+func (c *RecontraCommand) PlayTurnAs(playerId game.PlayerId, game *game.BeloteGame) error {
+	return game.CallRecontra(playerId, c.Called)
+}
+
+func newRecontraCommand(cmdBytes []byte) (*RecontraCommand, error) {
+	recontraCmd := &RecontraCommand{}
+
+	err := json.Unmarshal(cmdBytes, recontraCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return recontraCmd, nil
+}
@@ -8,12 +8,18 @@ import (
 
 type SelectTrumpCommand struct {
 	Suit *game.Suit
+	// Contract, when set, takes the hand in no trumps or all trumps instead
+	// of a suit.
+	Contract *game.Contract
 }
 
 const SelectTrumpCmdType = "selectTrump"
 
-func (c *SelectTrumpCommand) PlayTurnAs(playerId game.PlayerId, game *game.BeloteGame) error {
-	return game.SelectTrump(playerId, c.Suit)
+func (c *SelectTrumpCommand) PlayTurnAs(playerId game.PlayerId, gm *game.BeloteGame) error {
+	if c.Contract != nil {
+		return gm.SelectContract(playerId, *c.Contract)
+	}
+	return gm.SelectTrump(playerId, c.Suit)
 }
 
 func newSelectTrumpCommand(cmdBytes []byte) (*SelectTrumpCommand, error) {
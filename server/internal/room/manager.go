@@ -3,6 +3,9 @@ package room
 import (
 	"strconv"
 	"sync"
+	"time"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
 )
 
 type RoomManager struct {
@@ -19,11 +22,11 @@ func NewRoomManager() RoomManager {
 	}
 }
 
-func (m *RoomManager) CreateRoom() *Room {
+func (m *RoomManager) CreateRoom(rules game.RuleSet, handSummaryTimeout time.Duration) *Room {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	roomId := m.idGen.getNextRoomId()
-	room := NewRoom(strconv.Itoa(roomId))
+	room := NewRoom(strconv.Itoa(roomId), rules, handSummaryTimeout)
 	m.rooms[room.Id] = room
 	return room
 }
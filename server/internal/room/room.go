@@ -6,6 +6,7 @@ import (
 	"log"
 	"math/rand/v2"
 	"sync"
+	"time"
 
 	"github.com/los-dogos-studio/gurian-belote/game"
 	"github.com/los-dogos-studio/gurian-belote/server/internal/room/gamecmd"
@@ -18,9 +19,14 @@ type Room struct {
 
 	started bool
 
+	handSummaryTimeout time.Duration
+	handSummaryTimer   *time.Timer
+
 	mu sync.Mutex
 }
 
+const DefaultHandSummaryTimeout = 30 * time.Second
+
 type messageSender interface {
 	SendMessage(msg []byte) error
 }
@@ -35,16 +41,20 @@ var (
 	ErrRoomFull           = errors.New("room: room is full")
 	ErrPlayerNotFound     = errors.New("room: player not found")
 	ErrTeamsNotBalanced   = errors.New("room: teams are not balanced")
+	ErrRoomNotFull        = errors.New("room: not every seat is taken")
+	ErrNoTeams            = errors.New("room: players play without teams")
 	ErrGameAlreadyStarted = errors.New("room: game already started")
 )
 
-func NewRoom(id string) *Room {
+func NewRoom(id string, rules game.RuleSet, handSummaryTimeout time.Duration) *Room {
 	return &Room{
-		Id:      id,
-		Game:    game.NewBeloteGame(),
-		Users:   make(map[string]UserData),
-		started: false,
-		mu:      sync.Mutex{},
+		Id:                 id,
+		Game:               game.NewBeloteGame(rules),
+		Users:              make(map[string]UserData),
+		started:            false,
+		handSummaryTimeout: handSummaryTimeout,
+		handSummaryTimer:   nil,
+		mu:                 sync.Mutex{},
 	}
 }
 
@@ -60,13 +70,20 @@ func (r *Room) Join(userId string, conn messageSender) error {
 		return ErrGameAlreadyStarted
 	}
 
-	if len(r.Users) >= game.NUM_PLAYERS {
+	table := r.Game.GetRules().Table()
+	if len(r.Users) >= table.Players() {
 		return ErrRoomFull
 	}
 
+	// Without partnerships, the team is the seat drawn when the game starts.
+	team := game.Team1
+	if !table.HasPartnerships() {
+		team = game.NoTeamId
+	}
+
 	r.Users[userId] = UserData{
 		playerId: game.NoPlayerId,
-		team:     game.Team1,
+		team:     team,
 		conn:     conn,
 	}
 
@@ -85,6 +102,10 @@ func (r *Room) ChooseTeam(userId string, team game.TeamId) error {
 		return ErrGameAlreadyStarted
 	}
 
+	if !r.Game.GetRules().Table().HasPartnerships() {
+		return ErrNoTeams
+	}
+
 	r.Users[userId] = UserData{
 		playerId: r.Users[userId].playerId,
 		team:     team,
@@ -98,7 +119,53 @@ func (r *Room) PlayTurn(userId string, gameCmd gamecmd.PlayableCmd) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	return gameCmd.PlayTurnAs(r.Users[userId].playerId, &r.Game)
+	err := gameCmd.PlayTurnAs(r.Users[userId].playerId, &r.Game)
+	if err != nil {
+		return err
+	}
+
+	r.updateHandSummaryTimer()
+	return nil
+}
+
+// updateHandSummaryTimer deals the next hand once the hand summary has been
+// shown for handSummaryTimeout, even if some players did not acknowledge it.
+func (r *Room) updateHandSummaryTimer() {
+	if r.Game.GetState() != game.GameHandSummary {
+		if r.handSummaryTimer != nil {
+			r.handSummaryTimer.Stop()
+			r.handSummaryTimer = nil
+		}
+		return
+	}
+
+	if r.handSummaryTimer != nil {
+		return
+	}
+
+	handNumber := r.Game.GetHandNumber()
+	r.handSummaryTimer = time.AfterFunc(r.handSummaryTimeout, func() {
+		r.endHandSummary(handNumber)
+	})
+}
+
+func (r *Room) endHandSummary(handNumber int) {
+	r.mu.Lock()
+	if r.Game.GetState() != game.GameHandSummary || r.Game.GetHandNumber() != handNumber {
+		r.mu.Unlock()
+		return
+	}
+
+	err := r.Game.EndHandSummary()
+	r.handSummaryTimer = nil
+	r.mu.Unlock()
+
+	if err != nil {
+		log.Println("Error ending hand summary:", err)
+		return
+	}
+
+	r.BroadcastState()
 }
 
 func (r *Room) StartGame() error {
@@ -158,6 +225,11 @@ func (r *Room) BroadcastState() {
 }
 
 func (r *Room) assignPlayerIds() error {
+	table := r.Game.GetRules().Table()
+	if !table.HasPartnerships() {
+		return r.assignSeats(table)
+	}
+
 	if err := r.checkBalance(); err != nil {
 		return err
 	}
@@ -188,6 +260,24 @@ func (r *Room) assignPlayerIds() error {
 	return nil
 }
 
+// assignSeats seats every user at random at a table without partnerships,
+// each playing as their own team.
+func (r *Room) assignSeats(table game.Table) error {
+	if len(r.Users) != table.Players() {
+		return ErrRoomNotFull
+	}
+
+	seats := table.PlayerIds()
+	rand.Shuffle(len(seats), func(i, j int) { seats[i], seats[j] = seats[j], seats[i] })
+	for user, userData := range r.Users {
+		userData.playerId, seats = seats[0], seats[1:]
+		userData.team = table.Team(userData.playerId)
+		r.Users[user] = userData
+	}
+
+	return nil
+}
+
 func (r *Room) checkBalance() error {
 	team1Count, team2Count := 0, 0
 	for _, userData := range r.Users {
@@ -1,10 +1,28 @@
 package userconn
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/los-dogos-studio/gurian-belote/game"
+	"github.com/los-dogos-studio/gurian-belote/server/internal/room"
+)
+
 type CreateRoomCmd struct {
+	Rules string
+	// HandSummaryTimeout is in seconds, the room default is used when unset.
+	HandSummaryTimeout int
 }
 
 func NewCreateRoomCmd(msg []byte) (Cmd, error) {
-	return &CreateRoomCmd{}, nil
+	createRoomCmd := CreateRoomCmd{}
+
+	err := json.Unmarshal(msg, &createRoomCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createRoomCmd, nil
 }
 
 func (c *CreateRoomCmd) HandleCommand(context *CmdContext) error {
@@ -15,7 +33,22 @@ func (c *CreateRoomCmd) HandleCommand(context *CmdContext) error {
 		return ErrUserAlreadyInRoom
 	}
 
-	userRoom := roomManager.CreateRoom()
+	rulesPreset := c.Rules
+	if rulesPreset == "" {
+		rulesPreset = game.StandardRuleSetPreset
+	}
+
+	rules, ok := game.GetRuleSetPreset(rulesPreset)
+	if !ok {
+		return ErrUnknownRuleSet
+	}
+
+	handSummaryTimeout := room.DefaultHandSummaryTimeout
+	if c.HandSummaryTimeout > 0 {
+		handSummaryTimeout = time.Duration(c.HandSummaryTimeout) * time.Second
+	}
+
+	userRoom := roomManager.CreateRoom(rules, handSummaryTimeout)
 
 	err := userRoom.Join(user.UserId, user)
 	if err != nil {
@@ -11,4 +11,5 @@ var (
 	ErrUserAlreadyInRoom = errors.New("user already in room")
 	ErrUserNotInRoom     = errors.New("user not in room")
 	ErrRoomNotFound      = errors.New("room not found")
+	ErrUnknownRuleSet    = errors.New("unknown rule set")
 )